@@ -1,23 +1,43 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/asallaram/cbb-analytics/internal/api"
+	"github.com/asallaram/cbb-analytics/internal/config"
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/health"
 	"github.com/asallaram/cbb-analytics/internal/storage"
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 )
 
+// shutdownTimeout is how long in-flight requests get to finish after a
+// SIGINT or SIGTERM.
+const shutdownTimeout = 15 * time.Second
+
 func main() {
-	mongoURI := os.Getenv("MONGO_URI")
-	if mongoURI == "" {
-		mongoURI = "mongodb://localhost:27017"
+	configFile := flag.String("config", "", "settings file (default: .env if present)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("Invalid configuration:\n", err)
 	}
 
-	mongo, err := storage.NewMongoDB(mongoURI, "cbb_analytics")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mongo, err := storage.NewMongoDB(cfg.MongoURI, cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to MongoDB:", err)
 	}
@@ -26,26 +46,69 @@ func main() {
 	router := mux.NewRouter()
 	h := api.NewHandler(mongo)
 
+	// The API only serves stored data, so ESPN being down is reported but
+	// does not take it out of rotation.
+	checker := health.New()
+	checker.Add("mongo", mongo.Ping)
+	checker.AddOptional("espn", health.Cached(espn.NewClient(cfg.ESPNBaseURL).Ping, time.Minute))
+
+	router.HandleFunc("/healthz", checker.Healthz).Methods("GET")
+	router.HandleFunc("/readyz", checker.Readyz).Methods("GET")
+
 	router.HandleFunc("/api/games", h.GetGames).Methods("GET")
 	router.HandleFunc("/api/games/{id}", h.GetGame).Methods("GET")
 	router.HandleFunc("/api/games/{id}/plays", h.GetPlays).Methods("GET")
 	router.HandleFunc("/api/games/{id}/stats", h.GetStats).Methods("GET")
 	router.HandleFunc("/api/games/{id}/zones", h.GetZones).Methods("GET")
+	router.HandleFunc("/api/games/{id}/boxscore", h.GetBoxScore).Methods("GET")
+	router.HandleFunc("/api/games/{id}/discrepancies", h.GetDiscrepancies).Methods("GET")
+	router.HandleFunc("/api/games/{id}/play-edits", h.GetPlayEdits).Methods("GET")
 	router.HandleFunc("/api/games/{id}/insights", h.GetInsights).Methods("GET")
 
 	c := cors.New(cors.Options{
-		AllowedOrigins: []string{"*"},
+		AllowedOrigins: cfg.CORSOrigins,
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders: []string{"*"},
 	})
 
-	corsHandler := c.Handler(router)
+	// The admin endpoints take a bearer token and are left out of CORS, so
+	// no browser page can call them on an operator's behalf.
+	root := mux.NewRouter()
+	admin := root.PathPrefix("/api/admin").Subrouter()
+	admin.Use(api.RequireToken(cfg.AdminToken))
+	admin.HandleFunc("/ingest-errors", h.GetIngestErrors).Methods("GET")
+	admin.HandleFunc("/schema-drift", h.GetSchemaDrift).Methods("GET")
+	root.PathPrefix("/").Handler(c.Handler(router))
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           root,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       60 * time.Second,
 	}
 
-	log.Printf("🚀 API Server running on :%s\n", port)
-	log.Fatal(http.ListenAndServe(":"+port, corsHandler))
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("🚀 API Server running on %s\n", srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		mongo.Close()
+		log.Fatal("API server failed: ", err)
+	case <-ctx.Done():
+	}
+
+	log.Println("Shutting down, finishing in-flight requests...")
+	checker.Drain()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("Shutdown did not complete cleanly: %v", err)
+	}
+	log.Println("API server stopped")
 }
@@ -0,0 +1,239 @@
+// Command backfill ingests the final games of past days, walking the ESPN
+// scoreboard (or a -data-dir of game files) day by day and running each
+// final game through the full ingest pipeline. Each fully ingested day is
+// checkpointed, so an interrupted backfill resumes where it stopped when run
+// again.
+//
+//	go run ./cmd/backfill -from 20260301 -to 20260315
+//	go run ./cmd/backfill -season 2026
+//	go run ./cmd/backfill -season 2026 -league womens-college-basketball
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/archive"
+	"github.com/asallaram/cbb-analytics/internal/config"
+	"github.com/asallaram/cbb-analytics/internal/drift"
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/ingest"
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
+	"github.com/asallaram/cbb-analytics/internal/storage"
+)
+
+// gameTimeout bounds the summary fetch and all writes for one game.
+const gameTimeout = 30 * time.Second
+
+// unplayed are the final statuses of games that never tipped off and so
+// have no plays or box score to ingest.
+var unplayed = map[string]bool{"STATUS_POSTPONED": true, "STATUS_CANCELED": true}
+
+type backfiller struct {
+	source   source.DataSource
+	db       *storage.MongoDB
+	pipeline *ingest.Pipeline
+	league   espn.League
+
+	// force re-ingests checkpointed days and finalized games.
+	force bool
+}
+
+func main() {
+	fromFlag := flag.String("from", "", "first day to backfill, YYYYMMDD")
+	toFlag := flag.String("to", "", "last day to backfill, YYYYMMDD (default: yesterday)")
+	season := flag.Int("season", 0, "backfill a whole season, named by the year it ends in (e.g. 2026)")
+	leagueFlag := flag.String("league", string(espn.MensCollegeBasketball), "league to backfill")
+	force := flag.Bool("force", false, "re-ingest days already checkpointed and games already finalized")
+	archiveDir := flag.String("archive-dir", "", "local archive directory when ARCHIVE_PAYLOADS is set (default: ARCHIVE_DIR, else GridFS bucket)")
+	dataDir := flag.String("data-dir", "", "read games from JSON files in this directory instead of ESPN (default: DATA_DIR)")
+	configFile := flag.String("config", "", "settings file (default: .env if present)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("Invalid configuration:\n", err)
+	}
+	if *archiveDir == "" {
+		*archiveDir = cfg.ArchiveDir
+	}
+	if *dataDir == "" {
+		*dataDir = cfg.DataDir
+	}
+
+	league, err := espn.ParseLeague(*leagueFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	yesterday := espn.GameDay(time.Now()).AddDate(0, 0, -1)
+
+	var from, to time.Time
+	switch {
+	case *season != 0 && *fromFlag != "":
+		log.Fatal("Use either -season or -from/-to, not both")
+	case *season != 0:
+		from, to = seasonRange(league, *season)
+	case *fromFlag != "":
+		if from, err = time.Parse(dayLayout, *fromFlag); err != nil {
+			log.Fatal("Invalid -from:", err)
+		}
+		to = yesterday
+		if *toFlag != "" {
+			if to, err = time.Parse(dayLayout, *toFlag); err != nil {
+				log.Fatal("Invalid -to:", err)
+			}
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if to.After(yesterday) {
+		to = yesterday
+	}
+	if from.After(to) {
+		log.Fatalf("Nothing to backfill between %s and %s", from.Format(dayLayout), to.Format(dayLayout))
+	}
+
+	mongo, err := storage.NewMongoDB(cfg.MongoURI, cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to MongoDB:", err)
+	}
+	defer mongo.Close()
+
+	ctx := context.Background()
+
+	var src source.DataSource = source.Dir{Root: *dataDir}
+	if *dataDir == "" {
+		client := espn.NewClient(cfg.ESPNBaseURL)
+		if cfg.ArchivePayloads {
+			client.Recorder = archive.Recorder{Store: archive.Open(*archiveDir, mongo.DB)}
+		}
+		client.Drift = drift.NewRecorder(mongo)
+		src = source.ESPN{Client: client}
+	}
+
+	pipeline := ingest.NewPipeline(mongo)
+	pipeline.Thresholds = cfg.Insights
+
+	b := &backfiller{
+		source:   src,
+		db:       mongo,
+		pipeline: pipeline,
+		league:   league,
+		force:    *force,
+	}
+
+	if err := b.run(ctx, from, to); err != nil {
+		log.Fatal("Backfill stopped: ", err)
+	}
+}
+
+// run backfills every day from through to that has no checkpoint yet.
+func (b *backfiller) run(ctx context.Context, from, to time.Time) error {
+	done := make(map[string]bool)
+	if !b.force {
+		checkpoints, err := b.db.GetCheckpoints(ctx, string(b.league), from.Format(dayLayout), to.Format(dayLayout))
+		if err != nil {
+			return fmt.Errorf("loading checkpoints: %w", err)
+		}
+		for _, cp := range checkpoints {
+			done[cp.Date] = true
+		}
+	}
+
+	all := days(from, to)
+	fmt.Printf("📚 Backfilling %s from %s to %s (%d days, %d already done)\n",
+		b.league, all[0], all[len(all)-1], len(all), len(done))
+
+	for _, day := range all {
+		if done[day] {
+			continue
+		}
+		if err := b.backfillDay(ctx, day); err != nil {
+			if errors.Is(err, espn.ErrCircuitOpen) {
+				return fmt.Errorf("%s: %w; run again later to resume", day, err)
+			}
+			log.Printf("Day %s incomplete, it will be retried on the next run: %v", day, err)
+		}
+	}
+	return nil
+}
+
+// backfillDay ingests the final games on one day's scoreboard. The day is
+// checkpointed only if every final game made it in.
+func (b *backfiller) backfillDay(ctx context.Context, day string) error {
+	games, err := b.source.Games(ctx, string(b.league), day)
+	if err != nil {
+		return fmt.Errorf("fetching scoreboard: %w", err)
+	}
+
+	cp := models.Checkpoint{League: string(b.league), Date: day, Games: len(games)}
+	var failed int
+	for _, game := range games {
+		if game.Status != "post" || unplayed[game.StatusName] {
+			continue
+		}
+
+		ingested, err := b.backfillGame(ctx, game)
+		if errors.Is(err, espn.ErrCircuitOpen) {
+			return err
+		}
+		if err != nil {
+			log.Printf("Error backfilling %s: %v", game.ID, err)
+			failed++
+			continue
+		}
+		if ingested {
+			cp.Ingested++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d games failed", failed, cp.Games)
+	}
+
+	cp.CompletedAt = time.Now()
+	if err := b.db.SaveCheckpoint(ctx, &cp); err != nil {
+		return fmt.Errorf("saving checkpoint: %w", err)
+	}
+
+	fmt.Printf("[%s] %d games, %d ingested\n", day, cp.Games, cp.Ingested)
+	return nil
+}
+
+// backfillGame ingests one final game, skipping it if an earlier run or the
+// poller already finalized it.
+func (b *backfiller) backfillGame(ctx context.Context, game models.Game) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, gameTimeout)
+	defer cancel()
+
+	if !b.force {
+		if stored, err := b.db.GetGame(ctx, game.ID); err == nil && stored.Finalized {
+			return false, nil
+		}
+	}
+
+	if err := b.db.UpsertGame(ctx, &game); err != nil {
+		return false, fmt.Errorf("saving game: %w", err)
+	}
+
+	data, err := b.source.Game(ctx, string(b.league), game.ID)
+	if err != nil {
+		return false, fmt.Errorf("fetching game: %w", err)
+	}
+
+	res, err := b.pipeline.Finalize(ctx, data)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("   %s\n   └─ Saved %s\n", game.Matchup(), res)
+	return true, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+)
+
+const dayLayout = "20060102"
+
+// seasonRange returns the first and last days worth scanning for a season.
+// Seasons are named by the year they end in, as ESPN does: -season 2026 is
+// the 2025-26 college season. The windows are generous; days without games
+// cost one empty scoreboard request.
+func seasonRange(league espn.League, season int) (from, to time.Time) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	switch league {
+	case espn.NBA:
+		return day(season-1, time.October, 1), day(season, time.June, 30)
+	case espn.WNBA:
+		return day(season, time.May, 1), day(season, time.October, 31)
+	default:
+		return day(season-1, time.November, 1), day(season, time.April, 15)
+	}
+}
+
+// days lists every day from through to inclusive as YYYYMMDD.
+func days(from, to time.Time) []string {
+	var out []string
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		out = append(out, d.Format(dayLayout))
+	}
+	return out
+}
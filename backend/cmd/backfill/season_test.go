@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+)
+
+func TestSeasonRange(t *testing.T) {
+	tests := []struct {
+		league   espn.League
+		from, to string
+	}{
+		{espn.MensCollegeBasketball, "20251101", "20260415"},
+		{espn.WomensCollegeBasketball, "20251101", "20260415"},
+		{espn.NBA, "20251001", "20260630"},
+		{espn.WNBA, "20260501", "20261031"},
+	}
+
+	for _, tt := range tests {
+		from, to := seasonRange(tt.league, 2026)
+		if got := from.Format(dayLayout); got != tt.from {
+			t.Errorf("%s: expected season to start %s, got %s", tt.league, tt.from, got)
+		}
+		if got := to.Format(dayLayout); got != tt.to {
+			t.Errorf("%s: expected season to end %s, got %s", tt.league, tt.to, got)
+		}
+	}
+}
+
+func TestDays(t *testing.T) {
+	from, to := seasonRange(espn.MensCollegeBasketball, 2024)
+	all := days(from, to)
+
+	// 2023-11-01 through 2024-04-15, across a leap day.
+	if len(all) != 167 {
+		t.Errorf("expected 167 days, got %d", len(all))
+	}
+	if all[0] != "20231101" || all[len(all)-1] != "20240415" {
+		t.Errorf("unexpected bounds %s..%s", all[0], all[len(all)-1])
+	}
+	for _, d := range all {
+		if d == "20240229" {
+			return
+		}
+	}
+	t.Error("expected the leap day to be included")
+}
@@ -0,0 +1,27 @@
+// Command config validates the settings the API server and poller would run
+// with and prints them, with the Mongo password masked. It exits non-zero
+// listing every invalid setting.
+//
+//	go run ./cmd/config
+//	go run ./cmd/config -config prod.env
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/asallaram/cbb-analytics/internal/config"
+)
+
+func main() {
+	configFile := flag.String("config", "", "settings file (default: .env if present)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(cfg.Dump())
+}
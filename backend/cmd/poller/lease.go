@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Only one poller replica ingests at a time. The active one holds a lease
+// in Mongo and renews it every leaseRenew; standbys keep trying and take
+// over once the lease lapses, at most leaseTTL after the holder dies.
+//
+// A worker writes for up to gameTimeout after it last checks the lease, so
+// the TTL covers that plus a renewal period and some slack: while renewals
+// succeed, the hold always has at least gameTimeout left to run.
+const (
+	leaseName  = "poller"
+	leaseRenew = 10 * time.Second
+	leaseSlack = 5 * time.Second
+	leaseTTL   = gameTimeout + leaseRenew + leaseSlack
+)
+
+var errStandby = errors.New("standby: another poller holds the lease")
+
+// leaseStore is the slice of storage the lease needs.
+type leaseStore interface {
+	AcquireLease(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+}
+
+type lease struct {
+	store  leaseStore
+	holder string
+
+	// expires is when our hold runs out by our own clock, in Unix
+	// nanoseconds; zero when we do not hold the lease. It is measured from
+	// before each renewal request so it never outlives the stored expiry.
+	expires atomic.Int64
+}
+
+// newLease holds the lease as holder, or as hostname-pid if holder is empty.
+func newLease(store leaseStore, holder string) *lease {
+	if holder == "" {
+		host, _ := os.Hostname()
+		holder = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	return &lease{store: store, holder: holder}
+}
+
+// Held reports whether this replica is the active poller.
+func (l *lease) Held() bool {
+	return l.HeldFor(0)
+}
+
+// HeldFor reports whether this replica will still hold the lease d from
+// now, barring a renewal.
+func (l *lease) HeldFor(d time.Duration) bool {
+	return time.Now().Add(d).UnixNano() < l.expires.Load()
+}
+
+// Check is the lease's readiness check; standbys report errStandby.
+func (l *lease) Check(context.Context) error {
+	if !l.Held() {
+		return errStandby
+	}
+	return nil
+}
+
+// renew takes or extends the lease and reports whether this replica holds
+// it afterwards. A failed request leaves the current hold to run out.
+func (l *lease) renew(ctx context.Context) bool {
+	now := time.Now()
+	ok, err := l.store.AcquireLease(ctx, leaseName, l.holder, now, leaseTTL)
+	if err != nil {
+		log.Printf("Error renewing poller lease: %v", err)
+		return l.Held()
+	}
+
+	was := l.Held()
+	if ok {
+		l.expires.Store(now.Add(leaseTTL).UnixNano())
+	} else {
+		l.expires.Store(0)
+	}
+	if ok != was {
+		if ok {
+			log.Printf("Poller %s is now active", l.holder)
+		} else {
+			log.Printf("Poller %s is on standby", l.holder)
+		}
+	}
+	return ok
+}
+
+// run renews the lease until ctx is cancelled, then releases it so a
+// standby can take over straight away.
+func (l *lease) run(ctx context.Context) {
+	ticker := time.NewTicker(leaseRenew)
+	defer ticker.Stop()
+
+	for {
+		l.renew(ctx)
+
+		select {
+		case <-ctx.Done():
+			l.release()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *lease) release() {
+	if !l.Held() {
+		return
+	}
+	l.expires.Store(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := l.store.ReleaseLease(ctx, leaseName, l.holder); err != nil {
+		log.Printf("Error releasing poller lease: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memLeases mimics storage.MongoDB's lease semantics in memory.
+type memLeases struct {
+	mu      sync.Mutex
+	holder  string
+	expires time.Time
+}
+
+func (m *memLeases) AcquireLease(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder != "" && m.holder != holder && now.Before(m.expires) {
+		return false, nil
+	}
+	m.holder, m.expires = holder, now.Add(ttl)
+	return true, nil
+}
+
+func (m *memLeases) ReleaseLease(ctx context.Context, name, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder == holder {
+		m.holder = ""
+	}
+	return nil
+}
+
+func TestLeaseFailover(t *testing.T) {
+	ctx := context.Background()
+	store := &memLeases{}
+	a := &lease{store: store, holder: "a"}
+	b := &lease{store: store, holder: "b"}
+
+	if !a.renew(ctx) || !a.Held() {
+		t.Fatal("expected the first replica to take the lease")
+	}
+	if b.renew(ctx) || b.Held() {
+		t.Fatal("expected the second replica to stand by")
+	}
+	if err := b.Check(ctx); err != errStandby {
+		t.Errorf("expected standby readiness error, got %v", err)
+	}
+
+	// a dies without releasing: b takes over once the lease lapses.
+	store.mu.Lock()
+	store.expires = time.Now().Add(-time.Second)
+	store.mu.Unlock()
+	if !b.renew(ctx) {
+		t.Fatal("expected the standby to take over an expired lease")
+	}
+	if a.renew(ctx) || a.Held() {
+		t.Error("expected the old holder to learn it lost the lease")
+	}
+
+	// b shuts down cleanly: a takes over straight away.
+	b.release()
+	if b.Held() {
+		t.Error("expected release to drop the hold")
+	}
+	if !a.renew(ctx) {
+		t.Error("expected a released lease to be taken immediately")
+	}
+}
+
+func TestLeaseHeldFor(t *testing.T) {
+	l := &lease{store: &memLeases{}, holder: "a"}
+	if !l.renew(context.Background()) {
+		t.Fatal("expected to take the lease")
+	}
+
+	// A fresh hold covers a whole game's writes.
+	if !l.HeldFor(gameTimeout) {
+		t.Errorf("expected a fresh %v lease to cover a %v game", leaseTTL, gameTimeout)
+	}
+	if l.HeldFor(leaseTTL) {
+		t.Error("expected the hold not to outlast the TTL")
+	}
+
+	// One missed renewal still leaves a game's worth of hold.
+	l.expires.Store(time.Now().Add(leaseTTL - leaseRenew).UnixNano())
+	if !l.HeldFor(gameTimeout) {
+		t.Error("expected the hold to cover a game until the next renewal")
+	}
+}
@@ -1,143 +1,565 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
-	"github.com/asallaram/cbb-analytics/internal/analyzer"
+	"github.com/asallaram/cbb-analytics/internal/archive"
+	"github.com/asallaram/cbb-analytics/internal/config"
+	"github.com/asallaram/cbb-analytics/internal/drift"
 	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/health"
+	"github.com/asallaram/cbb-analytics/internal/ingest"
 	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
 	"github.com/asallaram/cbb-analytics/internal/storage"
 )
 
+// gameTimeout bounds the summary fetch and all writes for one live game.
+const gameTimeout = 20 * time.Second
+
+// shutdownTimeout is how long the tick in progress gets to finish its writes
+// after a SIGINT or SIGTERM before they are cancelled.
+const shutdownTimeout = 25 * time.Second
+
+type poller struct {
+	source   source.DataSource
+	db       *storage.MongoDB
+	pipeline *ingest.Pipeline
+	leagues  []espn.League
+	workers  int
+
+	// liveInterval is how often a live game's summary is fetched outside
+	// halftime and crunch time.
+	liveInterval time.Duration
+
+	// breaker is the ESPN client's circuit breaker, nil for other sources.
+	// While it is open, ticks and workers skip work rather than queue up
+	// requests that would fail.
+	breaker *espn.CircuitBreaker
+
+	// stopping is closed on shutdown; workers stop picking up new games but
+	// finish the one they are on.
+	stopping <-chan struct{}
+
+	// lease decides whether this replica is the active poller. active is
+	// what the last tick saw, to notice taking over.
+	lease  *lease
+	active bool
+
+	// boards maps each scoreboard to its next fetch; games holds every live
+	// game and its next summary fetch. Both are only touched between ingestion
+	// rounds, from the scheduler goroutine.
+	boards map[boardKey]time.Time
+	games  map[string]*liveGame
+
+	// archive, when payloads are archived, is pruned of anything older
+	// than retention; pruneDue is when the next pass runs.
+	archive   archive.Store
+	retention time.Duration
+	pruneDue  time.Time
+}
+
+type boardKey struct {
+	league espn.League
+	date   string
+}
+
+// liveGame is one unit of work for the ingestion pool.
+type liveGame struct {
+	league espn.League
+	id     string
+	game   models.Game
+	due    time.Time
+
+	// finalized is set by the worker once a final summary has been ingested
+	// and the game marked finalized.
+	finalized bool
+
+	// failing means the game has an open entry in the ingest error journal,
+	// closed by its next successful ingestion.
+	failing bool
+}
+
 func main() {
-	client := espn.NewClient("https://site.api.espn.com")
+	replaySource := flag.String("replay", "", "replay a recorded game summary (JSON file or archived game ID) instead of polling")
+	speed := flag.Float64("speed", 1, "replay speed multiplier, e.g. 1, 10 or 100")
+	finalize := flag.String("finalize", "", "re-run the finalization pass for a final game ID and exit")
+	gameLeague := flag.String("league", string(espn.MensCollegeBasketball), "league of the replayed or finalized game")
+	configFile := flag.String("config", "", "settings file (default: .env if present)")
+	flag.Parse()
 
-	mongoURI := os.Getenv("MONGO_URI")
-	if mongoURI == "" {
-		mongoURI = "mongodb://localhost:27017"
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("Invalid configuration:\n", err)
 	}
 
-	mongo, err := storage.NewMongoDB(mongoURI, "cbb_analytics")
+	// stop is cancelled by a signal and only stops new work from starting.
+	// work carries in-flight writes and is cancelled only if they overrun
+	// shutdownTimeout.
+	stop, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	ctx, cancelWork := context.WithCancel(context.Background())
+	defer cancelWork()
+
+	mongo, err := storage.NewMongoDB(cfg.MongoURI, cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to MongoDB:", err)
 	}
 	defer mongo.Close()
 
+	store := archive.Open(cfg.ArchiveDir, mongo.DB)
+
+	pipeline := ingest.NewPipeline(mongo)
+	pipeline.Thresholds = cfg.Insights
+
+	if *replaySource != "" {
+		if *speed <= 0 {
+			log.Fatal("-speed must be positive")
+		}
+		league, err := espn.ParseLeague(*gameLeague)
+		if err != nil {
+			log.Fatal(err)
+		}
+		data, err := loadReplay(ctx, *replaySource, league, store)
+		if err != nil {
+			log.Fatal("Failed to load replay:", err)
+		}
+		err = newReplayer(mongo, pipeline, data, *speed, cfg.LiveInterval).run(stop)
+		if errors.Is(err, context.Canceled) {
+			log.Println("Replay stopped")
+			return
+		}
+		if err != nil {
+			log.Fatal("Replay failed:", err)
+		}
+		return
+	}
+
+	// DATA_DIR reads games from JSON files instead of ESPN.
+	var (
+		src     source.DataSource
+		breaker *espn.CircuitBreaker
+	)
+	if cfg.DataDir != "" {
+		src = source.Dir{Root: cfg.DataDir}
+	} else {
+		client := espn.NewClient(cfg.ESPNBaseURL)
+		if cfg.ArchivePayloads {
+			client.Recorder = archive.Recorder{Store: store}
+		}
+		client.Drift = drift.NewRecorder(mongo)
+		src = source.ESPN{Client: client}
+		breaker = client.Breaker
+	}
+
+	p := &poller{
+		source:       src,
+		db:           mongo,
+		pipeline:     pipeline,
+		leagues:      cfg.Leagues,
+		workers:      cfg.PollerWorkers,
+		liveInterval: cfg.LiveInterval,
+		breaker:      breaker,
+		stopping:     stop.Done(),
+		lease:        newLease(mongo, cfg.PollerID),
+		boards:       make(map[boardKey]time.Time),
+		games:        make(map[string]*liveGame),
+	}
+	if cfg.ArchivePayloads {
+		p.archive = store
+		p.retention = cfg.ArchiveRetention
+	}
+
+	if *finalize != "" {
+		league, err := espn.ParseLeague(*gameLeague)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := p.finalizeGame(ctx, league, *finalize); err != nil {
+			log.Fatal("Finalization failed:", err)
+		}
+		return
+	}
+
 	fmt.Println("🏀 Live Game Poller Started!")
-	fmt.Println("Polling ESPN every 30 seconds for live games...")
+	fmt.Printf("Polling %s for live games in %v on a game-state schedule...\n", sourceName(src), cfg.Leagues)
 
-	ticker := time.NewTicker(30 * time.Second)
+	checker := health.New()
+	checker.Add("mongo", mongo.Ping)
+	checker.AddOptional("lease", p.lease.Check)
+	if breaker != nil {
+		checker.Add("espn", func(context.Context) error {
+			if breaker.Open() {
+				return espn.ErrCircuitOpen
+			}
+			return nil
+		})
+	}
+	healthSrv, err := serveHealth(cfg.HealthAddr, checker)
+	if err != nil {
+		mongo.Close()
+		log.Fatal("Failed to start health server: ", err)
+	}
+
+	leaseCtx, releaseLease := context.WithCancel(context.Background())
+	leaseDone := make(chan struct{})
+	p.lease.renew(ctx)
+	go func() {
+		defer close(leaseDone)
+		p.lease.run(leaseCtx)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.run(stop, ctx)
+	}()
+
+	<-stop.Done()
+	log.Println("Shutting down, finishing in-flight games...")
+	checker.Drain()
+
+	select {
+	case <-done:
+	case <-time.After(shutdownTimeout):
+		log.Println("In-flight games did not finish in time, cancelling")
+		cancelWork()
+		<-done
+	}
+
+	// Only hand the lease over once our writes are done.
+	releaseLease()
+	<-leaseDone
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	healthSrv.Shutdown(shutdownCtx)
+	log.Println("Poller stopped")
+}
+
+// run ticks until stop is cancelled. A tick in progress when that happens
+// runs to completion under work.
+func (p *poller) run(stop, work context.Context) {
+	ticker := time.NewTicker(schedulerTick)
 	defer ticker.Stop()
 
-	pollGames(client, mongo)
+	now := time.Now()
+	for {
+		p.tick(work, now)
 
-	for range ticker.C {
-		pollGames(client, mongo)
+		select {
+		case <-stop.Done():
+			return
+		case now = <-ticker.C:
+		}
 	}
 }
 
-func pollGames(client *espn.Client, mongo *storage.MongoDB) {
-	today := time.Now()
-	yesterday := today.AddDate(0, 0, -1)
+// sourceName describes where the poller reads games from.
+func sourceName(src source.DataSource) string {
+	if dir, ok := src.(source.Dir); ok {
+		return dir.Root
+	}
+	return "ESPN"
+}
 
-	for _, date := range []time.Time{yesterday, today} {
-		dateStr := date.Format("20060102")
+// serveHealth exposes /healthz and /readyz on addr. The poller is ready
+// while Mongo answers and, when polling ESPN, its breaker is closed. The
+// address is bound before returning, so a port already in use is an error
+// rather than probes that silently never answer.
+func serveHealth(addr string, checker *health.Checker) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", checker.Healthz)
+	mux.HandleFunc("GET /readyz", checker.Readyz)
 
-		scoreboard, err := client.GetScoreboard(dateStr)
-		if err != nil {
-			log.Printf("Error fetching scoreboard for %s: %v", dateStr, err)
-			continue
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Health server stopped: %v", err)
 		}
+	}()
+	return srv, nil
+}
+
+// tick refreshes the scoreboards that are due, then ingests the live games
+// that are due in a bounded worker pool. It returns only when all work is
+// done, so ticks never overlap; a slow tick just drops the ticker's missed
+// ticks. On a standby replica, one without the lease, it does nothing.
+func (p *poller) tick(ctx context.Context, now time.Time) {
+	if !p.lease.Held() {
+		p.active = false
+		return
+	}
+	if !p.active {
+		// Another replica may have been ingesting while this one stood by,
+		// so start from a clean schedule and full first ingestions.
+		p.active = true
+		clear(p.boards)
+		clear(p.games)
+		p.pipeline.Reset()
+	}
+	p.pruneArchive(ctx, now)
 
-		if date.Format("20060102") == today.Format("20060102") {
-			fmt.Printf("\n[%s] Found %d games today\n", time.Now().Format("15:04:05"), len(scoreboard.Events))
+	// Scoreboard days are Eastern, whatever zone the poller runs in.
+	today := espn.ScoreboardDate(now)
+	yesterday := espn.ScoreboardDate(now.In(espn.Eastern).AddDate(0, 0, -1))
+
+	for key := range p.boards {
+		if key.date != today && key.date != yesterday {
+			delete(p.boards, key)
 		}
+	}
 
-		for _, event := range scoreboard.Events {
-			if len(event.Competitions) == 0 {
+	for _, league := range p.leagues {
+		for _, date := range []string{yesterday, today} {
+			key := boardKey{league: league, date: date}
+			due, seen := p.boards[key]
+			if seen && (due.IsZero() || now.Before(due)) {
 				continue
 			}
+			if err := p.pollBoard(ctx, key, now, date == today); errors.Is(err, espn.ErrCircuitOpen) {
+				log.Printf("ESPN circuit open, skipping this tick")
+				return
+			}
+		}
+	}
 
-			comp := event.Competitions[0]
-			status := event.Status.Type.State
-
-			game := convertEventToGame(event, comp)
+	var due []*liveGame
+	for _, g := range p.games {
+		if !now.Before(g.due) {
+			due = append(due, g)
+		}
+	}
+	due = append(due, p.dueRetries(ctx, now)...)
+	p.ingestLiveGames(ctx, due)
+	p.reschedule(due, now)
+}
 
-			if err := mongo.UpsertGame(&game); err != nil {
-				log.Printf("Error saving game %s: %v", game.ID, err)
+// reschedule sets the next summary fetch of each game in a finished round
+// and drops the finalized ones. A retried game the poller was not tracking
+// is tracked from now on if it turned out to be live, so it keeps being
+// polled after its journal entry is resolved.
+func (p *poller) reschedule(round []*liveGame, now time.Time) {
+	for _, g := range round {
+		if g.finalized {
+			delete(p.games, g.id)
+			continue
+		}
+		interval, live := summaryInterval(g.league, g.game, p.liveInterval)
+		if _, tracked := p.games[g.id]; !tracked {
+			if !live {
 				continue
 			}
+			p.games[g.id] = g
+		}
+		if !live {
+			// A final game whose finalization failed; try again later.
+			interval = p.liveInterval
+		}
+		g.due = now.Add(interval)
+	}
+}
 
-			if status == "in" && date.Format("20060102") == today.Format("20060102") {
-				fmt.Printf("🔴 LIVE: %s (fetching plays...)\n", event.Name)
+// pollBoard refreshes the stored games for one scoreboard, starts tracking
+// any that have gone live or just gone final, and schedules the board's next
+// fetch. A past day's board whose games are all final is never fetched again.
+func (p *poller) pollBoard(ctx context.Context, key boardKey, now time.Time, today bool) error {
+	games, err := p.source.Games(ctx, string(key.league), key.date)
+	if errors.Is(err, espn.ErrCircuitOpen) {
+		return err
+	}
+	if err != nil {
+		log.Printf("Error fetching scoreboard for %s: %v", key.date, err)
+		p.boards[key] = now.Add(preGameInterval)
+		return err
+	}
 
-				summary, err := client.GetGameSummary(game.ID)
-				if err != nil {
-					log.Printf("Error fetching summary for %s: %v", game.ID, err)
-					continue
-				}
+	for _, game := range games {
+		finishing := game.Status == "post" && p.needsFinalizing(ctx, game.ID)
 
-				if err := mongo.UpsertPlays(game.ID, summary.Plays); err != nil {
-					log.Printf("Error saving plays for %s: %v", game.ID, err)
-					continue
-				}
+		if err := p.db.UpsertGame(ctx, &game); err != nil {
+			log.Printf("Error saving game %s: %v", game.ID, err)
+			continue
+		}
 
-				stats := analyzer.CalculatePlayerStats(summary.Plays)
-				if err := mongo.UpsertPlayerStats(stats); err != nil {
-					log.Printf("Error saving stats for %s: %v", game.ID, err)
-					continue
-				}
+		tracked, ok := p.games[game.ID]
+		switch {
+		case ok:
+			tracked.game = game
+			if game.Status == "post" {
+				tracked.due = now
+			}
+		case game.Status == "in" || finishing:
+			p.games[game.ID] = &liveGame{league: key.league, id: game.ID, game: game, due: now}
+		}
+	}
 
-				zones := analyzer.CalculateZoneStats(summary.Plays)
-				if err := mongo.UpsertZoneStats(zones); err != nil {
-					log.Printf("Error saving zones for %s: %v", game.ID, err)
-					continue
-				}
+	next := boardInterval(games, now)
+	if !today && boardSettled(games) {
+		p.boards[key] = time.Time{}
+	} else {
+		p.boards[key] = now.Add(next)
+	}
 
-				generator := analyzer.NewInsightGenerator(summary.Plays)
-				insights := generator.GenerateInsights(game.ID)
-				if err := mongo.SaveInsights(insights); err != nil {
-					log.Printf("Error saving insights for %s: %v", game.ID, err)
+	if today {
+		fmt.Printf("\n[%s] Found %d %s games today, next scoreboard check in %v\n",
+			now.Format("15:04:05"), len(games), key.league, next.Round(time.Second))
+	}
+	return nil
+}
+
+// needsFinalizing reports whether a game the scoreboard shows as final was
+// last stored as in progress without having been finalized, i.e. it went
+// final between two polls of its summary or while the poller was down.
+// Games that were never seen live are left to the backfill.
+func (p *poller) needsFinalizing(ctx context.Context, gameID string) bool {
+	if _, tracked := p.games[gameID]; tracked {
+		return false
+	}
+	stored, err := p.db.GetGame(ctx, gameID)
+	if err != nil {
+		return false
+	}
+	return stored.Status == "in" && !stored.Finalized
+}
+
+// ingestLiveGames fans the live games out to p.workers goroutines. Each game
+// has its own deadline, so one slow game only ever holds up its own worker.
+// Each worker only writes to the games it was handed.
+func (p *poller) ingestLiveGames(ctx context.Context, games []*liveGame) {
+	jobs := make(chan *liveGame)
+	var wg sync.WaitGroup
+
+	for i := 0; i < min(p.workers, len(games)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for g := range jobs {
+				if p.breaker.Open() || !p.lease.Held() {
 					continue
 				}
-
-				fmt.Printf("   └─ Saved %d plays, %d players, %d zones, %d insights\n",
-					len(summary.Plays), len(stats), len(zones), len(insights))
+				p.ingestLiveGame(ctx, g)
 			}
+		}()
+	}
+
+feed:
+	for _, g := range games {
+		select {
+		case <-p.stopping:
+			break feed
+		case jobs <- g:
 		}
 	}
+	close(jobs)
+	wg.Wait()
 }
 
-func convertEventToGame(event espn.Event, comp espn.Competition) models.Game {
-	game := models.Game{
-		ID:            event.ID,
-		Date:          event.Date,
-		Status:        event.Status.Type.State,
-		CurrentPeriod: event.Status.Period,
-		CurrentClock:  event.Status.DisplayClock,
-		LastUpdated:   time.Now(),
+// ingestLiveGame fetches one game's data and runs it through the ingest
+// pipeline, all under a single per-game deadline. The fetched game also
+// refreshes the stored one, so live scores and status stay current without
+// refetching the scoreboard. Once it shows the game final, this is its
+// finalization pass. A failed pipeline run, or any failure of a game that
+// is already being retried, goes to the ingest error journal. Nothing is
+// written unless the lease is still held for the whole write stage.
+func (p *poller) ingestLiveGame(ctx context.Context, g *liveGame) error {
+	ctx, cancel := context.WithTimeout(ctx, gameTimeout)
+	defer cancel()
+
+	data, err := p.source.Game(ctx, string(g.league), g.id)
+	if err != nil {
+		log.Printf("Error fetching game %s: %v", g.id, err)
+		if g.failing && !errors.Is(err, espn.ErrCircuitOpen) {
+			p.journal(ctx, g, err)
+		}
+		return err
+	}
+
+	// The fetch may have outlasted our hold on the lease. Only write if the
+	// hold covers the rest of the game's deadline, so none of these writes
+	// can land after another replica has taken over.
+	if deadline, _ := ctx.Deadline(); !p.lease.HeldFor(time.Until(deadline)) {
+		log.Printf("Lost the poller lease, not saving game %s", g.id)
+		return errStandby
 	}
 
-	for _, competitor := range comp.Competitors {
-		if competitor.HomeAway == "home" {
-			game.HomeTeamID = competitor.Team.ID
-			game.HomeTeamName = competitor.Team.DisplayName
-			game.HomeScore = parseInt(competitor.Score)
-		} else {
-			game.AwayTeamID = competitor.Team.ID
-			game.AwayTeamName = competitor.Team.DisplayName
-			game.AwayScore = parseInt(competitor.Score)
+	g.game = data.Game
+	if err := p.db.UpsertGame(ctx, &data.Game); err != nil {
+		log.Printf("Error saving game %s: %v", g.id, err)
+	}
+
+	if g.game.Status == "post" {
+		res, err := p.pipeline.Finalize(ctx, data)
+		if err != nil {
+			log.Printf("Error finalizing %s: %v", g.id, err)
+			p.journal(ctx, g, err)
+			return err
 		}
+		p.resolve(ctx, g)
+		g.finalized = true
+		fmt.Printf("🏁 FINAL: %s\n   └─ Saved %s\n", g.game.Matchup(), res)
+		return nil
+	}
+
+	res, err := p.pipeline.Ingest(ctx, data)
+	if err != nil {
+		log.Printf("Error ingesting %s: %v", g.id, err)
+		p.journal(ctx, g, err)
+		return err
 	}
+	p.resolve(ctx, g)
 
-	return game
+	fmt.Printf("🔴 LIVE: %s\n   └─ Saved %s\n", g.game.Matchup(), res)
+	return nil
 }
 
-func parseInt(s string) int {
-	var val int
-	fmt.Sscanf(s, "%d", &val)
-	return val
+// finalizeGame forces a finalization pass for one game, whether or not it
+// was finalized before. It is the only way a finalized game is re-ingested
+// from its source.
+func (p *poller) finalizeGame(ctx context.Context, league espn.League, gameID string) error {
+	ctx, cancel := context.WithTimeout(ctx, gameTimeout)
+	defer cancel()
+
+	data, err := p.source.Game(ctx, string(league), gameID)
+	if err != nil {
+		return fmt.Errorf("fetching game: %w", err)
+	}
+
+	game := data.Game
+	if game.Status != "post" {
+		return fmt.Errorf("game %s is not final (status %q)", gameID, game.Status)
+	}
+	if err := p.db.UpsertGame(ctx, &game); err != nil {
+		return fmt.Errorf("saving game: %w", err)
+	}
+
+	res, err := p.pipeline.Finalize(ctx, data)
+	if err != nil {
+		return err
+	}
+	if err := p.db.ResolveIngestError(ctx, gameID, time.Now()); err != nil {
+		log.Printf("Error resolving journaled failure of %s: %v", gameID, err)
+	}
+	fmt.Printf("🏁 FINAL: %s %s\n   └─ Saved %s\n", gameID, game.Matchup(), res)
+	return nil
 }
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// pruneEvery is how often the poller deletes archived payloads older than
+// the retention period, and pruneTimeout bounds each pass.
+const (
+	pruneEvery   = time.Hour
+	pruneTimeout = time.Minute
+)
+
+// pruneArchive deletes archived payloads older than p.retention, at most
+// once every pruneEvery. Failures are logged and retried on the next pass.
+func (p *poller) pruneArchive(ctx context.Context, now time.Time) {
+	if p.archive == nil || p.retention <= 0 || now.Before(p.pruneDue) {
+		return
+	}
+	p.pruneDue = now.Add(pruneEvery)
+
+	ctx, cancel := context.WithTimeout(ctx, pruneTimeout)
+	defer cancel()
+
+	pruned, err := p.archive.Prune(ctx, now.Add(-p.retention))
+	if err != nil {
+		log.Printf("Error pruning archived payloads: %v", err)
+	}
+	if pruned > 0 {
+		log.Printf("Pruned %d archived payloads older than %v", pruned, p.retention)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/archive"
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/ingest"
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
+	"github.com/asallaram/cbb-analytics/internal/storage"
+)
+
+// replayPrefix marks the IDs of a replayed game and its plays. A replay is
+// stored as a game of its own, so it never clears or rewrites the real
+// game's data, and no scoreboard ever lists it for the live poller.
+const replayPrefix = "replay-"
+
+// replayer feeds a recorded game's plays through the live ingest path as if
+// they were arriving now, compressing time by speed.
+type replayer struct {
+	db       *storage.MongoDB
+	pipeline *ingest.Pipeline
+	data     *source.GameData
+	speed    float64
+	interval time.Duration
+}
+
+func newReplayer(db *storage.MongoDB, pipeline *ingest.Pipeline, data *source.GameData, speed float64, liveInterval time.Duration) *replayer {
+	// Ingest as often as the live poller would see new data, sped up, but
+	// never more than once a second.
+	interval := max(time.Duration(float64(liveInterval)/speed), time.Second)
+
+	pipeline.SkipBoxScore = true
+	return &replayer{
+		db:       db,
+		pipeline: pipeline,
+		data:     asReplay(data),
+		speed:    speed,
+		interval: interval,
+	}
+}
+
+// loadReplay reads an ESPN summary from a JSON (or .json.gz) file, or, if
+// from is not a file, from the latest archived summary for that game ID.
+func loadReplay(ctx context.Context, from string, league espn.League, store archive.Store) (*source.GameData, error) {
+	body, err := os.ReadFile(from)
+	if os.IsNotExist(err) {
+		body, _, err = archive.Latest(ctx, store, string(league), espn.KindSummary, from)
+	} else if err == nil && strings.HasSuffix(from, ".gz") {
+		body, err = gunzip(body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", from, err)
+	}
+
+	summary, err := espn.DecodeSummary(body)
+	if err != nil {
+		return nil, err
+	}
+	return source.FromSummary(league, summary)
+}
+
+// asReplay copies a game's data under replay IDs, flagged as a replay so
+// game listings leave it out.
+func asReplay(data *source.GameData) *source.GameData {
+	replay := *data
+	replay.Game.ID = replayPrefix + data.Game.ID
+	replay.Game.Replay = true
+	replay.BoxScore = nil
+
+	replay.Plays = make([]models.Play, len(data.Plays))
+	for i, play := range data.Plays {
+		play.ID = replayPrefix + play.ID
+		play.GameID = replay.Game.ID
+		replay.Plays[i] = play
+	}
+	return &replay
+}
+
+func gunzip(body []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+func (r *replayer) run(ctx context.Context) error {
+	game := r.data.Game
+	gameID := game.ID
+	plays := r.data.Plays
+	if len(plays) == 0 {
+		return fmt.Errorf("game %s has no plays to replay", gameID)
+	}
+	finalStatus := game.Status
+
+	if err := r.db.DeleteGameData(ctx, gameID); err != nil {
+		return fmt.Errorf("clearing previous replay: %w", err)
+	}
+
+	offsets := playOffsets(plays)
+	fmt.Printf("⏪ Replaying %s (%d plays over %s) at %gx\n",
+		gameID, len(plays), offsets[len(offsets)-1].Round(time.Second), r.speed)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	began := time.Now()
+	fed := 0
+	for fed < len(plays) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		elapsed := time.Duration(float64(time.Since(began)) * r.speed)
+		due := fed
+		for due < len(plays) && offsets[due] <= elapsed {
+			due++
+		}
+		if due == fed {
+			continue
+		}
+		fed = due
+
+		// A step's writes finish even if the replay is stopped part way
+		// through; the loop exits at the next tick instead.
+		step := context.WithoutCancel(ctx)
+
+		last := plays[fed-1]
+		game.Status = "in"
+		if fed == len(plays) {
+			game.Status = finalStatus
+		}
+		game.CurrentPeriod = last.Period
+		game.CurrentClock = last.Clock
+		game.HomeScore = last.HomeScore
+		game.AwayScore = last.AwayScore
+		game.LastUpdated = time.Now()
+
+		if err := r.db.UpsertGame(step, &game); err != nil {
+			log.Printf("Error saving game %s: %v", gameID, err)
+			continue
+		}
+
+		partial := *r.data
+		partial.Game = game
+		partial.Plays = plays[:fed]
+
+		// The last step finalizes a finished game, as the live poller would
+		// when the real game went final.
+		feed := r.pipeline.Ingest
+		if fed == len(plays) && game.Status == "post" {
+			feed = r.pipeline.Finalize
+		}
+		res, err := feed(step, &partial)
+		if err != nil {
+			log.Printf("Error ingesting %s: %v", gameID, err)
+			continue
+		}
+
+		fmt.Printf("[%s] P%d %s %d-%d └─ Saved %s\n", time.Now().Format("15:04:05"),
+			last.Period, last.Clock, last.AwayScore, last.HomeScore, res)
+	}
+
+	fmt.Printf("✅ Replay of %s complete\n", gameID)
+	return nil
+}
+
+// playOffsets returns each play's wallclock offset from the first play.
+// Plays without a usable wallclock inherit the previous play's offset, and
+// offsets never go backwards.
+func playOffsets(plays []models.Play) []time.Duration {
+	offsets := make([]time.Duration, len(plays))
+
+	var start time.Time
+	var prev time.Duration
+	for i, play := range plays {
+		t, err := time.Parse(time.RFC3339, play.Timestamp)
+		if err == nil && start.IsZero() {
+			start = t
+		}
+		if err == nil && t.Sub(start) > prev {
+			prev = t.Sub(start)
+		}
+		offsets[i] = prev
+	}
+	return offsets
+}
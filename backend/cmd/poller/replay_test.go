@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
+)
+
+func TestPlayOffsets(t *testing.T) {
+	plays := []models.Play{
+		{Timestamp: ""},
+		{Timestamp: "2026-03-15T16:00:30Z"},
+		{Timestamp: "2026-03-15T16:01:00Z"},
+		{Timestamp: "garbage"},
+		{Timestamp: "2026-03-15T16:00:50Z"},
+		{Timestamp: "2026-03-15T16:02:00Z"},
+	}
+
+	want := []time.Duration{0, 0, 30 * time.Second, 30 * time.Second, 30 * time.Second, 90 * time.Second}
+	got := playOffsets(plays)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("play %d: expected offset %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestPlayOffsetsFixture(t *testing.T) {
+	summary, err := espntest.LoadSummary(espntest.League, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+
+	data, err := source.FromSummary(espn.MensCollegeBasketball, summary)
+	if err != nil {
+		t.Fatalf("converting fixture: %v", err)
+	}
+
+	offsets := playOffsets(data.Plays)
+	last := offsets[len(offsets)-1]
+	if last < time.Hour || last > 4*time.Hour {
+		t.Errorf("expected a full game to span hours of wallclock, got %v", last)
+	}
+}
+
+func TestAsReplay(t *testing.T) {
+	data := &source.GameData{
+		Game:     models.Game{ID: "401822893", Status: "post"},
+		Plays:    []models.Play{{ID: "4018228931", GameID: "401822893"}},
+		BoxScore: &models.BoxScore{GameID: "401822893"},
+	}
+
+	replay := asReplay(data)
+	if replay.Game.ID != "replay-401822893" || !replay.Game.Replay || replay.BoxScore != nil {
+		t.Errorf("unexpected replay game %+v", replay)
+	}
+	if p := replay.Plays[0]; p.ID != "replay-4018228931" || p.GameID != "replay-401822893" {
+		t.Errorf("unexpected replay play %+v", p)
+	}
+	if data.Game.ID != "401822893" || data.Plays[0].ID != "4018228931" {
+		t.Errorf("original data was modified: %+v", data)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/ingest"
+)
+
+// A game whose ingestion fails is journaled in ingest_errors and retried
+// with exponential backoff, from retryBase up to retryMax, until an
+// ingestion succeeds. After maxRetryAttempts failures it is left in the
+// journal for someone to look at.
+const (
+	retryBase        = 30 * time.Second
+	retryMax         = 30 * time.Minute
+	maxRetryAttempts = 10
+)
+
+// stageFetch is the journal stage for a retry that could not fetch the game.
+const stageFetch = "fetch"
+
+// journalTimeout bounds the journal writes, which may follow a failure
+// caused by the game's own deadline running out.
+const journalTimeout = 5 * time.Second
+
+// retryDelay returns how long to wait after a game's attempts-th failure
+// before retrying it, or false once retries are exhausted.
+func retryDelay(attempts int) (time.Duration, bool) {
+	if attempts >= maxRetryAttempts {
+		return 0, false
+	}
+	return min(retryBase<<max(attempts-1, 0), retryMax), true
+}
+
+// journal records a failed ingestion of g and schedules its next retry.
+func (p *poller) journal(ctx context.Context, g *liveGame, err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), journalTimeout)
+	defer cancel()
+
+	stage := ingest.Stage(err)
+	if stage == "" {
+		stage = stageFetch
+	}
+
+	now := time.Now()
+	entry, jerr := p.db.RecordIngestError(ctx, g.id, string(g.league), stage, err.Error(), now)
+	if jerr != nil {
+		log.Printf("Error journaling failed ingestion of %s: %v", g.id, jerr)
+		return
+	}
+	g.failing = true
+
+	var next time.Time
+	if delay, ok := retryDelay(entry.Attempts); ok {
+		next = now.Add(delay)
+	} else {
+		log.Printf("Giving up retrying %s after %d attempts", g.id, entry.Attempts)
+	}
+	if err := p.db.ScheduleIngestRetry(ctx, g.id, next); err != nil {
+		log.Printf("Error scheduling retry of %s: %v", g.id, err)
+	}
+}
+
+// resolve closes g's journal entry once it has been ingested successfully.
+func (p *poller) resolve(ctx context.Context, g *liveGame) {
+	if !g.failing {
+		return
+	}
+	if err := p.db.ResolveIngestError(ctx, g.id, time.Now()); err != nil {
+		log.Printf("Error resolving journaled failure of %s: %v", g.id, err)
+		return
+	}
+	g.failing = false
+	log.Printf("Recovered %s after earlier failed ingestion", g.id)
+}
+
+// dueRetries returns the journaled games due for a retry that the poller is
+// not already tracking, for this tick's round; reschedule tracks those that
+// turn out to be live. Tracked games are just flagged, so their next
+// scheduled ingestion closes the entry.
+func (p *poller) dueRetries(ctx context.Context, now time.Time) []*liveGame {
+	entries, err := p.db.GetDueIngestRetries(ctx, now)
+	if err != nil {
+		log.Printf("Error loading ingest retries: %v", err)
+		return nil
+	}
+
+	var games []*liveGame
+	for _, e := range entries {
+		if tracked, ok := p.games[e.GameID]; ok {
+			tracked.failing = true
+			continue
+		}
+		games = append(games, &liveGame{league: espn.League(e.League), id: e.GameID, due: now, failing: true})
+	}
+	return games
+}
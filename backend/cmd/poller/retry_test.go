@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+		ok       bool
+	}{
+		{1, 30 * time.Second, true},
+		{2, time.Minute, true},
+		{4, 4 * time.Minute, true},
+		{6, 16 * time.Minute, true},
+		{7, retryMax, true},
+		{maxRetryAttempts - 1, retryMax, true},
+		{maxRetryAttempts, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := retryDelay(tt.attempts)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryDelay(%d) = %v, %v; want %v, %v", tt.attempts, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRescheduleTracksRecoveredLiveGames(t *testing.T) {
+	now := time.Date(2026, 3, 15, 17, 0, 0, 0, time.UTC)
+	p := &poller{liveInterval: 30 * time.Second, games: make(map[string]*liveGame)}
+
+	finished := &liveGame{id: "401822893", game: models.Game{Status: "post"}, finalized: true}
+	p.games[finished.id] = finished
+
+	live := &liveGame{league: espn.MensCollegeBasketball, id: "401822894", game: models.Game{Status: "in"}, failing: true}
+	unfetched := &liveGame{league: espn.MensCollegeBasketball, id: "401822895", failing: true}
+
+	p.reschedule([]*liveGame{finished, live, unfetched}, now)
+
+	if _, ok := p.games[finished.id]; ok {
+		t.Error("expected the finalized game to be dropped")
+	}
+	if g, ok := p.games[live.id]; !ok || !g.due.Equal(now.Add(p.liveInterval)) {
+		t.Errorf("expected the recovered live game to be tracked and due in %v, got %+v", p.liveInterval, g)
+	}
+	if _, ok := p.games[unfetched.id]; ok {
+		t.Error("expected a retry that never fetched the game to be left to the journal")
+	}
+}
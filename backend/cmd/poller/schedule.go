@@ -0,0 +1,120 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// The scheduler wakes every schedulerTick and only touches ESPN for the
+// scoreboards and games whose next poll is due.
+const (
+	schedulerTick = 5 * time.Second
+
+	// Live game summaries outside the normal live interval, which is
+	// configured as POLL_LIVE_INTERVAL.
+	clutchInterval   = 10 * time.Second
+	halftimeInterval = 2 * time.Minute
+
+	// Scoreboards are only needed to notice games tipping off; a live game's
+	// status comes from its own summary.
+	preGameWindow   = 15 * time.Minute
+	preGameInterval = time.Minute
+	idleInterval    = 30 * time.Minute
+
+	// A game is in crunch time inside the last clutchClock of regulation (or
+	// any time in overtime) with the margin at clutchMargin points or fewer.
+	clutchClock  = 5 * time.Minute
+	clutchMargin = 8
+)
+
+const statusHalftime = "STATUS_HALFTIME"
+
+// summaryInterval returns how long to wait before fetching a game's summary
+// again, liveInterval unless the game is at halftime or in crunch time.
+// Games that are not in progress are never polled, which the false result
+// reports.
+func summaryInterval(league espn.League, g models.Game, liveInterval time.Duration) (time.Duration, bool) {
+	if g.Status != "in" {
+		return 0, false
+	}
+	if g.StatusName == statusHalftime {
+		return halftimeInterval, true
+	}
+	if isClutch(league, g) {
+		return clutchInterval, true
+	}
+	return liveInterval, true
+}
+
+func isClutch(league espn.League, g models.Game) bool {
+	margin := g.HomeScore - g.AwayScore
+	if margin < 0 {
+		margin = -margin
+	}
+	if margin > clutchMargin {
+		return false
+	}
+
+	regulation := league.RegulationPeriods()
+	if g.CurrentPeriod > regulation {
+		return true
+	}
+	if g.CurrentPeriod < regulation {
+		return false
+	}
+	remaining, ok := parseClock(g.CurrentClock)
+	return ok && remaining <= clutchClock
+}
+
+// parseClock reads ESPN's display clock, which is "12:56" above a minute and
+// "45.3" below it.
+func parseClock(s string) (time.Duration, bool) {
+	minutes, seconds, found := strings.Cut(s, ":")
+	if !found {
+		minutes, seconds = "0", s
+	}
+
+	m, err := strconv.Atoi(minutes)
+	if err != nil {
+		return 0, false
+	}
+	sec, err := strconv.ParseFloat(seconds, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(m)*time.Minute + time.Duration(sec*float64(time.Second)), true
+}
+
+// boardInterval returns how long to wait before fetching a scoreboard again,
+// given the games it held last time. Only scheduled games need it: the board
+// is polled every preGameInterval from preGameWindow before tip until they
+// go live, and every idleInterval otherwise to pick up schedule changes.
+func boardInterval(games []models.Game, now time.Time) time.Duration {
+	next := idleInterval
+	for _, g := range games {
+		if g.Status != "pre" {
+			continue
+		}
+		wait := preGameInterval
+		if !g.TipTime.IsZero() {
+			wait = max(g.TipTime.Sub(now)-preGameWindow, preGameInterval)
+		}
+		next = min(next, wait)
+	}
+	return next
+}
+
+// boardSettled reports whether a past day's scoreboard can be dropped: once
+// every game on it is final there is nothing left to pick up.
+func boardSettled(games []models.Game) bool {
+	for _, g := range games {
+		if g.Status != "post" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+func TestSummaryInterval(t *testing.T) {
+	const live = 45 * time.Second
+	tests := []struct {
+		name   string
+		league espn.League
+		game   models.Game
+		want   time.Duration
+		live   bool
+	}{
+		{"scheduled", espn.MensCollegeBasketball, models.Game{Status: "pre"}, 0, false},
+		{"final", espn.MensCollegeBasketball, models.Game{Status: "post"}, 0, false},
+		{"first half", espn.MensCollegeBasketball,
+			models.Game{Status: "in", CurrentPeriod: 1, CurrentClock: "2:10", HomeScore: 30, AwayScore: 29}, live, true},
+		{"halftime", espn.MensCollegeBasketball,
+			models.Game{Status: "in", StatusName: statusHalftime, CurrentPeriod: 1, CurrentClock: "0:00"}, halftimeInterval, true},
+		{"close late", espn.MensCollegeBasketball,
+			models.Game{Status: "in", CurrentPeriod: 2, CurrentClock: "4:59", HomeScore: 60, AwayScore: 64}, clutchInterval, true},
+		{"close under a minute", espn.MensCollegeBasketball,
+			models.Game{Status: "in", CurrentPeriod: 2, CurrentClock: "45.3", HomeScore: 60, AwayScore: 61}, clutchInterval, true},
+		{"blowout late", espn.MensCollegeBasketball,
+			models.Game{Status: "in", CurrentPeriod: 2, CurrentClock: "1:00", HomeScore: 80, AwayScore: 60}, live, true},
+		{"close early", espn.MensCollegeBasketball,
+			models.Game{Status: "in", CurrentPeriod: 2, CurrentClock: "12:56", HomeScore: 47, AwayScore: 53}, live, true},
+		{"overtime", espn.MensCollegeBasketball,
+			models.Game{Status: "in", CurrentPeriod: 3, CurrentClock: "4:00", HomeScore: 70, AwayScore: 72}, clutchInterval, true},
+		{"second quarter is not late", espn.WomensCollegeBasketball,
+			models.Game{Status: "in", CurrentPeriod: 2, CurrentClock: "3:00", HomeScore: 30, AwayScore: 30}, live, true},
+		{"fourth quarter", espn.WomensCollegeBasketball,
+			models.Game{Status: "in", CurrentPeriod: 4, CurrentClock: "3:00", HomeScore: 50, AwayScore: 52}, clutchInterval, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isLive := summaryInterval(tt.league, tt.game, live)
+			if got != tt.want || isLive != tt.live {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.want, tt.live, got, isLive)
+			}
+		})
+	}
+}
+
+func TestBoardInterval(t *testing.T) {
+	now := time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		games []models.Game
+		want  time.Duration
+	}{
+		{"no games", nil, idleInterval},
+		{"all final", []models.Game{{Status: "post"}, {Status: "post"}}, idleInterval},
+		{"live only", []models.Game{{Status: "in"}}, idleInterval},
+		{"tip in forty minutes", []models.Game{{Status: "pre", TipTime: now.Add(40 * time.Minute)}}, 25 * time.Minute},
+		{"tip in ten minutes", []models.Game{{Status: "pre", TipTime: now.Add(10 * time.Minute)}}, preGameInterval},
+		{"tip delayed", []models.Game{{Status: "pre", TipTime: now.Add(-5 * time.Minute)}}, preGameInterval},
+		{"tip tonight", []models.Game{{Status: "pre", TipTime: now.Add(6 * time.Hour)}}, idleInterval},
+		{"earliest tip wins", []models.Game{
+			{Status: "pre", TipTime: now.Add(3 * time.Hour)},
+			{Status: "pre", TipTime: now.Add(25 * time.Minute)},
+			{Status: "post"},
+		}, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardInterval(tt.games, now); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBoardSettled(t *testing.T) {
+	if !boardSettled([]models.Game{{Status: "post"}}) {
+		t.Error("expected a board of finals to be settled")
+	}
+	if boardSettled([]models.Game{{Status: "post"}, {Status: "in"}}) {
+		t.Error("expected a board with a live game to stay open")
+	}
+}
@@ -0,0 +1,154 @@
+// Command reprocess re-decodes and re-analyzes a game from its archived raw
+// ESPN payloads, without contacting ESPN.
+//
+//	go run ./cmd/reprocess -game 401822893
+//	go run ./cmd/reprocess -game 401822893 -at 2026-03-15T17:45:00Z
+//	go run ./cmd/reprocess -game 401822893 -list
+//
+// A payload older than the plays already stored is refused unless -force is
+// given, since it would roll the game's stats back.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/archive"
+	"github.com/asallaram/cbb-analytics/internal/config"
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/ingest"
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
+	"github.com/asallaram/cbb-analytics/internal/storage"
+)
+
+func main() {
+	gameID := flag.String("game", "", "ESPN game ID to reprocess (required)")
+	leagueFlag := flag.String("league", string(espn.MensCollegeBasketball), "league the game belongs to")
+	at := flag.String("at", "", "use the last payload fetched at or before this RFC 3339 time (default: latest)")
+	list := flag.Bool("list", false, "list archived fetches for the game and exit")
+	force := flag.Bool("force", false, "reprocess a payload older than the plays already stored")
+	archiveDir := flag.String("archive-dir", "", "local archive directory (default: ARCHIVE_DIR, else GridFS bucket)")
+	configFile := flag.String("config", "", "settings file (default: .env if present)")
+	flag.Parse()
+
+	if *gameID == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	league, err := espn.ParseLeague(*leagueFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("Invalid configuration:\n", err)
+	}
+	if *archiveDir == "" {
+		*archiveDir = cfg.ArchiveDir
+	}
+
+	mongo, err := storage.NewMongoDB(cfg.MongoURI, cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to MongoDB:", err)
+	}
+	defer mongo.Close()
+
+	ctx := context.Background()
+	store := archive.Open(*archiveDir, mongo.DB)
+
+	entries, err := store.List(ctx, string(league), espn.KindSummary, *gameID)
+	if err != nil {
+		log.Fatal("Failed to list archive:", err)
+	}
+	if len(entries) == 0 {
+		log.Fatalf("No archived summaries for %s %s", league, *gameID)
+	}
+
+	if *list {
+		for _, e := range entries {
+			fmt.Println(e.FetchedAt.Format(time.RFC3339Nano))
+		}
+		return
+	}
+
+	entry := entries[len(entries)-1]
+	if *at != "" {
+		cutoff, err := time.Parse(time.RFC3339, *at)
+		if err != nil {
+			log.Fatal("Invalid -at:", err)
+		}
+		entry, err = lastBefore(entries, cutoff)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	body, err := store.Get(ctx, entry)
+	if err != nil {
+		log.Fatal("Failed to read archived summary:", err)
+	}
+
+	summary, err := espn.DecodeSummary(body)
+	if err != nil {
+		log.Fatal("Failed to decode archived summary:", err)
+	}
+
+	data, err := source.FromSummary(league, summary)
+	if err != nil {
+		log.Fatal("Failed to convert archived summary:", err)
+	}
+
+	// An older payload would roll the stored stats back to that moment.
+	if !*force {
+		stored, err := mongo.GetPlaysByGame(ctx, *gameID)
+		if err != nil {
+			log.Fatal("Failed to load stored plays:", err)
+		}
+		if have, snapshot := lastSequence(stored), lastSequence(data.Plays); have > snapshot {
+			log.Fatalf("Payload fetched %s ends at play %d but plays up to %d are stored; use -force to reprocess it anyway",
+				entry.FetchedAt.Format(time.RFC3339), snapshot, have)
+		}
+	}
+
+	fmt.Printf("♻️  Reprocessing %s from payload fetched %s\n", *gameID, entry.FetchedAt.Format(time.RFC3339))
+
+	pipeline := ingest.NewPipeline(mongo)
+	pipeline.Thresholds = cfg.Insights
+	// An archived payload is a snapshot, not the feed's current state, so
+	// plays it lacks must not be recorded as deleted.
+	pipeline.SkipAudit = true
+	res, err := pipeline.Ingest(ctx, data)
+	if err != nil {
+		log.Fatalf("Error ingesting %s: %v", *gameID, err)
+	}
+
+	fmt.Printf("   └─ Saved %s\n", res)
+}
+
+// lastSequence returns the highest sequence number among plays.
+func lastSequence(plays []models.Play) int {
+	last := 0
+	for _, play := range plays {
+		if n, err := strconv.Atoi(play.SequenceNumber); err == nil {
+			last = max(last, n)
+		}
+	}
+	return last
+}
+
+func lastBefore(entries []archive.Entry, cutoff time.Time) (archive.Entry, error) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !entries[i].FetchedAt.After(cutoff) {
+			return entries[i], nil
+		}
+	}
+	return archive.Entry{}, fmt.Errorf("no archived summary at or before %s", cutoff.Format(time.RFC3339))
+}
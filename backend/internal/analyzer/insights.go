@@ -2,84 +2,88 @@ package analyzer
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
-	"github.com/asallaram/cbb-analytics/internal/espn"
 	"github.com/asallaram/cbb-analytics/internal/models"
 )
 
+// MomentumWindow is how many of the latest plays scoring runs are measured over.
+const MomentumWindow = 20
+
+// Thresholds decide when a stat line is notable enough for an insight.
+// Percentages run from 0 to 100.
+type Thresholds struct {
+	// HotFGPct and ColdFGPct apply from MinFGA attempts, ColdFGPct only
+	// from ColdMinFGA.
+	MinFGA     int
+	HotFGPct   float64
+	ColdMinFGA int
+	ColdFGPct  float64
+
+	HotThreePA  int
+	HotThreePct float64
+
+	// Zones are judged from ZoneMinAttempts; 0% is always cold.
+	ZoneMinAttempts int
+	ZoneHotPct      float64
+
+	// RunPoints scored by one team within MomentumWindow plays is a run,
+	// once the game has MinMomentumPlays plays.
+	MinMomentumPlays int
+	RunPoints        int
+
+	TurnoverTrouble int
+	FoulTrouble     int
+}
+
+var DefaultThresholds = Thresholds{
+	MinFGA:           5,
+	HotFGPct:         60,
+	ColdMinFGA:       8,
+	ColdFGPct:        30,
+	HotThreePA:       4,
+	HotThreePct:      50,
+	ZoneMinAttempts:  3,
+	ZoneHotPct:       60,
+	MinMomentumPlays: 10,
+	RunPoints:        10,
+	TurnoverTrouble:  4,
+	FoulTrouble:      4,
+}
+
 type InsightGenerator struct {
-	plays       []espn.Play
+	// Thresholds start out as DefaultThresholds.
+	Thresholds Thresholds
+
+	plays       []models.Play
 	playerStats map[string]*PlayerStats
 	zoneStats   map[string]*ZoneStats
 	playerNames map[string]string
 }
 
-func NewInsightGenerator(plays []espn.Play) *InsightGenerator {
-	return &InsightGenerator{
-		plays:       plays,
-		playerStats: CalculatePlayerStats(plays),
-		zoneStats:   CalculateZoneStats(plays),
-		playerNames: extractPlayerNames(plays),
-	}
+// NewInsightGenerator builds insights for a game's plays. playerNames maps
+// athlete IDs to display names, normally taken from the box score roster.
+func NewInsightGenerator(game models.Game, plays []models.Play, playerNames map[string]string) *InsightGenerator {
+	return NewInsightGeneratorFromTotals(plays, CalculatePlayerStats(game, plays), CalculateZoneStats(plays), playerNames)
 }
 
-func extractPlayerNames(plays []espn.Play) map[string]string {
-	names := make(map[string]string)
-
-	for _, play := range plays {
-		if len(play.Participants) == 0 {
-			continue
-		}
-
-		playerID := play.Participants[0].Athlete.ID
-		text := play.Text
-
-		// Skip non-player actions
-		if strings.Contains(text, "Timeout") ||
-			strings.Contains(text, "Team Rebound") ||
-			strings.Contains(text, "Official") ||
-			strings.Contains(text, "End of") ||
-			strings.HasPrefix(text, "Foul on") {
-			continue
-		}
-
-		// Extract name before action verbs
-		words := strings.Fields(text)
-		if len(words) < 2 {
-			continue
-		}
-
-		var nameParts []string
-		for _, word := range words {
-			lower := strings.ToLower(word)
-			// Stop at action words and trailing descriptors
-			if lower == "makes" || lower == "misses" || lower == "with" ||
-				lower == "turnover" || lower == "bad" || lower == "subbing" ||
-				lower == "defensive" || lower == "offensive" || lower == "block." ||
-				lower == "steal." || lower == "rebound." {
-				break
-			}
-			nameParts = append(nameParts, word)
-		}
-
-		if len(nameParts) >= 2 {
-			name := strings.Join(nameParts, " ")
-			// Remove trailing periods
-			name = strings.TrimRight(name, ".")
-			names[playerID] = name
-		}
+// NewInsightGeneratorFromTotals builds insights from stats already kept by
+// accumulators. recent only needs the last MomentumWindow plays of the game.
+func NewInsightGeneratorFromTotals(recent []models.Play, stats map[string]*PlayerStats, zones map[string]*ZoneStats, playerNames map[string]string) *InsightGenerator {
+	return &InsightGenerator{
+		Thresholds:  DefaultThresholds,
+		plays:       recent,
+		playerStats: stats,
+		zoneStats:   zones,
+		playerNames: playerNames,
 	}
-
-	return names
 }
 
 func (ig *InsightGenerator) getPlayerName(playerID string) string {
 	if name, exists := ig.playerNames[playerID]; exists {
 		return name
 	}
-	return "Player"
+	return "Player " + playerID
 }
 
 func (ig *InsightGenerator) GenerateInsights(gameID string) []models.Insight {
@@ -99,11 +103,11 @@ func (ig *InsightGenerator) detectHotColdPlayers(gameID string) []models.Insight
 	for playerID, stats := range ig.playerStats {
 		playerName := ig.getPlayerName(playerID)
 
-		if stats.FGA < 5 {
+		if stats.FGA < ig.Thresholds.MinFGA {
 			continue
 		}
 
-		if stats.FGPct >= 60 {
+		if stats.FGPct >= ig.Thresholds.HotFGPct {
 			insights = append(insights, models.Insight{
 				GameID:    gameID,
 				Timestamp: time.Now(),
@@ -113,8 +117,9 @@ func (ig *InsightGenerator) detectHotColdPlayers(gameID string) []models.Insight
 				Title:     fmt.Sprintf("%s on Fire", playerName),
 				Message:   fmt.Sprintf("%s shooting %d-%d (%.0f%%) from the field", playerName, stats.FGM, stats.FGA, stats.FGPct),
 				Context: models.Context{
-					PlayerID: playerID,
-					TeamID:   stats.TeamID,
+					PlayerID:   playerID,
+					PlayerName: playerName,
+					TeamID:     stats.TeamID,
 					Stats: map[string]interface{}{
 						"fgm":    stats.FGM,
 						"fga":    stats.FGA,
@@ -124,7 +129,7 @@ func (ig *InsightGenerator) detectHotColdPlayers(gameID string) []models.Insight
 			})
 		}
 
-		if stats.FGPct <= 30 && stats.FGA >= 8 {
+		if stats.FGPct <= ig.Thresholds.ColdFGPct && stats.FGA >= ig.Thresholds.ColdMinFGA {
 			insights = append(insights, models.Insight{
 				GameID:    gameID,
 				Timestamp: time.Now(),
@@ -134,8 +139,9 @@ func (ig *InsightGenerator) detectHotColdPlayers(gameID string) []models.Insight
 				Title:     fmt.Sprintf("%s Struggling", playerName),
 				Message:   fmt.Sprintf("%s shooting %d-%d (%.0f%%) from the field", playerName, stats.FGM, stats.FGA, stats.FGPct),
 				Context: models.Context{
-					PlayerID: playerID,
-					TeamID:   stats.TeamID,
+					PlayerID:   playerID,
+					PlayerName: playerName,
+					TeamID:     stats.TeamID,
 					Stats: map[string]interface{}{
 						"fgm":    stats.FGM,
 						"fga":    stats.FGA,
@@ -145,7 +151,7 @@ func (ig *InsightGenerator) detectHotColdPlayers(gameID string) []models.Insight
 			})
 		}
 
-		if stats.ThreePA >= 4 && stats.ThreePct >= 50 {
+		if stats.ThreePA >= ig.Thresholds.HotThreePA && stats.ThreePct >= ig.Thresholds.HotThreePct {
 			insights = append(insights, models.Insight{
 				GameID:    gameID,
 				Timestamp: time.Now(),
@@ -155,8 +161,9 @@ func (ig *InsightGenerator) detectHotColdPlayers(gameID string) []models.Insight
 				Title:     fmt.Sprintf("%s Lights Out from Three", playerName),
 				Message:   fmt.Sprintf("%s shooting %d-%d (%.0f%%) from beyond the arc", playerName, stats.ThreePM, stats.ThreePA, stats.ThreePct),
 				Context: models.Context{
-					PlayerID: playerID,
-					TeamID:   stats.TeamID,
+					PlayerID:   playerID,
+					PlayerName: playerName,
+					TeamID:     stats.TeamID,
 					Stats: map[string]interface{}{
 						"three_pm":  stats.ThreePM,
 						"three_pa":  stats.ThreePA,
@@ -177,7 +184,7 @@ func (ig *InsightGenerator) detectZonePerformance(gameID string) []models.Insigh
 		playerName := ig.getPlayerName(playerID)
 
 		for zone, data := range zoneStats.Zones {
-			if data.Attempts < 3 {
+			if data.Attempts < ig.Thresholds.ZoneMinAttempts {
 				continue
 			}
 
@@ -191,9 +198,10 @@ func (ig *InsightGenerator) detectZonePerformance(gameID string) []models.Insigh
 					Title:     "Zone Ice Cold",
 					Message:   fmt.Sprintf("%s 0-%d from %s", playerName, data.Attempts, zone),
 					Context: models.Context{
-						PlayerID: playerID,
-						TeamID:   zoneStats.TeamID,
-						Zone:     zone,
+						PlayerID:   playerID,
+						PlayerName: playerName,
+						TeamID:     zoneStats.TeamID,
+						Zone:       zone,
 						Stats: map[string]interface{}{
 							"makes":    data.Makes,
 							"attempts": data.Attempts,
@@ -203,7 +211,7 @@ func (ig *InsightGenerator) detectZonePerformance(gameID string) []models.Insigh
 				})
 			}
 
-			if data.Pct >= 60 && data.Attempts >= 3 {
+			if data.Pct >= ig.Thresholds.ZoneHotPct {
 				insights = append(insights, models.Insight{
 					GameID:    gameID,
 					Timestamp: time.Now(),
@@ -213,9 +221,10 @@ func (ig *InsightGenerator) detectZonePerformance(gameID string) []models.Insigh
 					Title:     "Zone Dominant",
 					Message:   fmt.Sprintf("%s %d-%d (%.0f%%) from %s", playerName, data.Makes, data.Attempts, data.Pct, zone),
 					Context: models.Context{
-						PlayerID: playerID,
-						TeamID:   zoneStats.TeamID,
-						Zone:     zone,
+						PlayerID:   playerID,
+						PlayerName: playerName,
+						TeamID:     zoneStats.TeamID,
+						Zone:       zone,
 						Stats: map[string]interface{}{
 							"makes":    data.Makes,
 							"attempts": data.Attempts,
@@ -233,24 +242,24 @@ func (ig *InsightGenerator) detectZonePerformance(gameID string) []models.Insigh
 func (ig *InsightGenerator) detectMomentum(gameID string) []models.Insight {
 	var insights []models.Insight
 
-	if len(ig.plays) < 10 {
+	if len(ig.plays) < ig.Thresholds.MinMomentumPlays {
 		return insights
 	}
 
 	recentPlays := ig.plays
-	if len(ig.plays) > 20 {
-		recentPlays = ig.plays[len(ig.plays)-20:]
+	if len(ig.plays) > MomentumWindow {
+		recentPlays = ig.plays[len(ig.plays)-MomentumWindow:]
 	}
 
 	teamScores := make(map[string]int)
 	for _, play := range recentPlays {
-		if play.ScoringPlay && play.Team != nil {
-			teamScores[play.Team.ID] += play.ScoreValue
+		if play.ScoringPlay && play.TeamID != "" {
+			teamScores[play.TeamID] += play.ScoreValue
 		}
 	}
 
 	for teamID, points := range teamScores {
-		if points >= 10 {
+		if points >= ig.Thresholds.RunPoints {
 			insights = append(insights, models.Insight{
 				GameID:    gameID,
 				Timestamp: time.Now(),
@@ -279,7 +288,7 @@ func (ig *InsightGenerator) detectStruggling(gameID string) []models.Insight {
 	for playerID, stats := range ig.playerStats {
 		playerName := ig.getPlayerName(playerID)
 
-		if stats.Turnovers >= 4 {
+		if stats.Turnovers >= ig.Thresholds.TurnoverTrouble {
 			insights = append(insights, models.Insight{
 				GameID:    gameID,
 				Timestamp: time.Now(),
@@ -289,8 +298,9 @@ func (ig *InsightGenerator) detectStruggling(gameID string) []models.Insight {
 				Title:     fmt.Sprintf("%s Turnover Issues", playerName),
 				Message:   fmt.Sprintf("%s with %d turnovers", playerName, stats.Turnovers),
 				Context: models.Context{
-					PlayerID: playerID,
-					TeamID:   stats.TeamID,
+					PlayerID:   playerID,
+					PlayerName: playerName,
+					TeamID:     stats.TeamID,
 					Stats: map[string]interface{}{
 						"turnovers": stats.Turnovers,
 					},
@@ -298,7 +308,7 @@ func (ig *InsightGenerator) detectStruggling(gameID string) []models.Insight {
 			})
 		}
 
-		if stats.Fouls >= 4 {
+		if stats.Fouls >= ig.Thresholds.FoulTrouble {
 			insights = append(insights, models.Insight{
 				GameID:    gameID,
 				Timestamp: time.Now(),
@@ -308,8 +318,9 @@ func (ig *InsightGenerator) detectStruggling(gameID string) []models.Insight {
 				Title:     fmt.Sprintf("%s in Foul Trouble", playerName),
 				Message:   fmt.Sprintf("%s with %d fouls", playerName, stats.Fouls),
 				Context: models.Context{
-					PlayerID: playerID,
-					TeamID:   stats.TeamID,
+					PlayerID:   playerID,
+					PlayerName: playerName,
+					TeamID:     stats.TeamID,
 					Stats: map[string]interface{}{
 						"fouls": stats.Fouls,
 					},
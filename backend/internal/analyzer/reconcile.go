@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// Discrepancy is one stat where the play-derived PlayerStats disagree with
+// ESPN's official box score.
+type Discrepancy struct {
+	GameID     string    `bson:"game_id" json:"game_id"`
+	PlayerID   string    `bson:"player_id" json:"player_id"`
+	PlayerName string    `bson:"player_name,omitempty" json:"player_name,omitempty"`
+	TeamID     string    `bson:"team_id" json:"team_id"`
+	Stat       string    `bson:"stat" json:"stat"`
+	Derived    int       `bson:"derived" json:"derived"`
+	Official   int       `bson:"official" json:"official"`
+	Diff       int       `bson:"diff" json:"diff"`
+	CheckedAt  time.Time `bson:"checked_at" json:"checked_at"`
+}
+
+// Reconcile diffs play-derived stats against the official box score. Players
+// who appear on only one side are compared against an empty line, so a
+// parsing rule that credits the wrong athlete shows up on both of them.
+func Reconcile(gameID string, stats map[string]*PlayerStats, box *models.BoxScore) []Discrepancy {
+	var discrepancies []Discrepancy
+	now := time.Now()
+
+	seen := make(map[string]bool)
+	for _, official := range box.Players {
+		if official.DidNotPlay {
+			continue
+		}
+		seen[official.PlayerID] = true
+
+		derived := stats[official.PlayerID]
+		if derived == nil {
+			derived = &PlayerStats{}
+		}
+
+		for _, d := range diffLine(derived, official.BoxScoreLine) {
+			d.GameID = gameID
+			d.PlayerID = official.PlayerID
+			d.PlayerName = official.PlayerName
+			d.TeamID = official.TeamID
+			d.CheckedAt = now
+			discrepancies = append(discrepancies, d)
+		}
+	}
+
+	for playerID, derived := range stats {
+		if seen[playerID] {
+			continue
+		}
+		for _, d := range diffLine(derived, models.BoxScoreLine{}) {
+			d.GameID = gameID
+			d.PlayerID = playerID
+			d.TeamID = derived.TeamID
+			d.CheckedAt = now
+			discrepancies = append(discrepancies, d)
+		}
+	}
+
+	return discrepancies
+}
+
+func diffLine(derived *PlayerStats, official models.BoxScoreLine) []Discrepancy {
+	pairs := []struct {
+		stat              string
+		derived, official int
+	}{
+		{"points", derived.Points, official.Points},
+		{"fgm", derived.FGM, official.FGM},
+		{"fga", derived.FGA, official.FGA},
+		{"three_pm", derived.ThreePM, official.ThreePM},
+		{"three_pa", derived.ThreePA, official.ThreePA},
+		{"ftm", derived.FTM, official.FTM},
+		{"fta", derived.FTA, official.FTA},
+		{"rebounds", derived.Rebounds, official.Rebounds},
+		{"assists", derived.Assists, official.Assists},
+		{"steals", derived.Steals, official.Steals},
+		{"blocks", derived.Blocks, official.Blocks},
+		{"turnovers", derived.Turnovers, official.Turnovers},
+		{"fouls", derived.Fouls, official.Fouls},
+	}
+
+	var out []Discrepancy
+	for _, p := range pairs {
+		if p.derived != p.official {
+			out = append(out, Discrepancy{
+				Stat:     p.stat,
+				Derived:  p.derived,
+				Official: p.official,
+				Diff:     p.derived - p.official,
+			})
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+func TestReconcile(t *testing.T) {
+	stats := map[string]*PlayerStats{
+		"1": {PlayerID: "1", TeamID: "127", Points: 12, FGM: 5, FGA: 9, FTM: 2, FTA: 2, Assists: 3},
+		"9": {PlayerID: "9", TeamID: "127", Rebounds: 1},
+	}
+	box := &models.BoxScore{
+		Players: []models.PlayerBoxScore{
+			{PlayerID: "1", TeamID: "127", BoxScoreLine: models.BoxScoreLine{Points: 12, FGM: 5, FGA: 10, FTM: 2, FTA: 2, Assists: 1}},
+			{PlayerID: "2", TeamID: "127", BoxScoreLine: models.BoxScoreLine{Assists: 2}},
+			{PlayerID: "3", TeamID: "127", DidNotPlay: true},
+		},
+	}
+
+	got := make(map[string]Discrepancy)
+	for _, d := range Reconcile("401822893", stats, box) {
+		got[d.PlayerID+"/"+d.Stat] = d
+	}
+
+	want := map[string]int{
+		"1/fga":      -1,
+		"1/assists":  2,
+		"2/assists":  -2,
+		"9/rebounds": 1,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d discrepancies, got %d: %+v", len(want), len(got), got)
+	}
+	for key, diff := range want {
+		d, ok := got[key]
+		if !ok {
+			t.Errorf("missing discrepancy %s", key)
+			continue
+		}
+		if d.Diff != diff || d.GameID != "401822893" {
+			t.Errorf("%s: expected diff %d, got %+v", key, diff, d)
+		}
+	}
+}
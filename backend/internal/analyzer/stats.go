@@ -3,95 +3,83 @@ package analyzer
 import (
 	"strings"
 
-	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
 )
 
 type PlayerStats struct {
-	GameID    string  `bson:"game_id" json:"game_id"`
-	PlayerID  string  `bson:"player_id" json:"player_id"`
-	TeamID    string  `bson:"team_id" json:"team_id"`
-	Points    int     `bson:"points" json:"points"`
-	FGM       int     `bson:"fgm" json:"fgm"`
-	FGA       int     `bson:"fga" json:"fga"`
-	FGPct     float64 `bson:"fg_pct" json:"fg_pct"`
-	ThreePM   int     `bson:"three_pm" json:"three_pm"`
-	ThreePA   int     `bson:"three_pa" json:"three_pa"`
-	ThreePct  float64 `bson:"three_pct" json:"three_pct"`
-	FTM       int     `bson:"ftm" json:"ftm"`
-	FTA       int     `bson:"fta" json:"fta"`
-	FTPct     float64 `bson:"ft_pct" json:"ft_pct"`
-	Rebounds  int     `bson:"rebounds" json:"rebounds"`
-	Assists   int     `bson:"assists" json:"assists"`
-	Steals    int     `bson:"steals" json:"steals"`
-	Blocks    int     `bson:"blocks" json:"blocks"`
-	Turnovers int     `bson:"turnovers" json:"turnovers"`
-	Fouls     int     `bson:"fouls" json:"fouls"`
+	GameID   string `bson:"game_id" json:"game_id"`
+	PlayerID string `bson:"player_id" json:"player_id"`
+	// PlayerName is resolved from the players registry when served.
+	PlayerName string  `bson:"-" json:"player_name,omitempty"`
+	TeamID     string  `bson:"team_id" json:"team_id"`
+	Points     int     `bson:"points" json:"points"`
+	FGM        int     `bson:"fgm" json:"fgm"`
+	FGA        int     `bson:"fga" json:"fga"`
+	FGPct      float64 `bson:"fg_pct" json:"fg_pct"`
+	ThreePM    int     `bson:"three_pm" json:"three_pm"`
+	ThreePA    int     `bson:"three_pa" json:"three_pa"`
+	ThreePct   float64 `bson:"three_pct" json:"three_pct"`
+	FTM        int     `bson:"ftm" json:"ftm"`
+	FTA        int     `bson:"fta" json:"fta"`
+	FTPct      float64 `bson:"ft_pct" json:"ft_pct"`
+	Rebounds   int     `bson:"rebounds" json:"rebounds"`
+	Assists    int     `bson:"assists" json:"assists"`
+	Steals     int     `bson:"steals" json:"steals"`
+	Blocks     int     `bson:"blocks" json:"blocks"`
+	Turnovers  int     `bson:"turnovers" json:"turnovers"`
+	Fouls      int     `bson:"fouls" json:"fouls"`
 }
 
-func CalculatePlayerStats(plays []espn.Play) map[string]*PlayerStats {
-	stats := make(map[string]*PlayerStats)
+func CalculatePlayerStats(game models.Game, plays []models.Play) map[string]*PlayerStats {
+	acc := NewStatsAccumulator(game)
+	acc.Add(plays)
+	return acc.Stats()
+}
 
-	for _, play := range plays {
-		if len(play.Participants) == 0 {
-			continue
-		}
+// StatsAccumulator keeps running player stats for one game, so a live
+// game's new plays can be folded in without replaying the whole game.
+type StatsAccumulator struct {
+	game  models.Game
+	stats map[string]*PlayerStats
+}
 
-		playerID := play.Participants[0].Athlete.ID
+// NewStatsAccumulator keeps stats for game, whose home and away teams are
+// used to place defenders credited on the offense's plays.
+func NewStatsAccumulator(game models.Game) *StatsAccumulator {
+	return &StatsAccumulator{game: game, stats: make(map[string]*PlayerStats)}
+}
 
-		if _, exists := stats[playerID]; !exists {
-			stats[playerID] = &PlayerStats{
-				GameID:   play.ID[:9],
-				PlayerID: playerID,
-				TeamID:   getTeamID(play),
-			}
-		}
+// Stats returns every player's running totals.
+func (a *StatsAccumulator) Stats() map[string]*PlayerStats {
+	return a.stats
+}
+
+// Add folds plays into the running totals and returns the stats lines they
+// touched.
+func (a *StatsAccumulator) Add(plays []models.Play) map[string]*PlayerStats {
+	touched := make(map[string]*PlayerStats)
 
-		s := stats[playerID]
-		playType := strings.ToLower(play.Type.Text)
-		playText := strings.ToLower(play.Text)
-
-		switch {
-		case strings.Contains(playType, "jumpshot") || strings.Contains(playType, "layupshot") || strings.Contains(playType, "dunkshot"):
-			if strings.Contains(playText, "makes") {
-				s.FGM++
-				s.Points += play.ScoreValue
-				if strings.Contains(playText, "three point") {
-					s.ThreePM++
+	for _, play := range plays {
+		for _, c := range Credits(a.game, play) {
+			s, exists := a.stats[c.PlayerID]
+			if !exists {
+				s = &PlayerStats{
+					GameID:   play.GameID,
+					PlayerID: c.PlayerID,
 				}
+				a.stats[c.PlayerID] = s
 			}
-			s.FGA++
-			if strings.Contains(playText, "three point") {
-				s.ThreePA++
-			}
-
-		case strings.Contains(playType, "freethrow"):
-			if strings.Contains(playText, "makes") {
-				s.FTM++
-				s.Points++
+			// A credit's team is unknown when the game's teams are; the
+			// player's first play that names one fills it in.
+			if s.TeamID == "" {
+				s.TeamID = c.TeamID
 			}
-			s.FTA++
-
-		case strings.Contains(playType, "rebound"):
-			s.Rebounds++
-
-		case strings.Contains(playText, "assists"):
-			s.Assists++
-
-		case strings.Contains(playType, "steal"):
-			s.Steals++
-
-		case strings.Contains(playType, "block"):
-			s.Blocks++
-
-		case strings.Contains(playType, "turnover"):
-			s.Turnovers++
-
-		case strings.Contains(playType, "foul"):
-			s.Fouls++
+			s.add(c.Line)
+			touched[c.PlayerID] = s
 		}
 	}
 
-	for _, s := range stats {
+	for _, s := range touched {
 		if s.FGA > 0 {
 			s.FGPct = float64(s.FGM) / float64(s.FGA) * 100
 		}
@@ -103,12 +91,120 @@ func CalculatePlayerStats(plays []espn.Play) map[string]*PlayerStats {
 		}
 	}
 
-	return stats
+	return touched
 }
 
-func getTeamID(play espn.Play) string {
-	if play.Team != nil {
-		return play.Team.ID
+func (s *PlayerStats) add(line models.BoxScoreLine) {
+	s.Points += line.Points
+	s.FGM += line.FGM
+	s.FGA += line.FGA
+	s.ThreePM += line.ThreePM
+	s.ThreePA += line.ThreePA
+	s.FTM += line.FTM
+	s.FTA += line.FTA
+	s.Rebounds += line.Rebounds
+	s.Assists += line.Assists
+	s.Steals += line.Steals
+	s.Blocks += line.Blocks
+	s.Turnovers += line.Turnovers
+	s.Fouls += line.Fouls
+}
+
+// Credit is one player's share of a play. A play can credit several players:
+// a made, assisted jumper credits the shooter with the attempt, the make and
+// the points, and the assister with an assist.
+type Credit struct {
+	PlayerID string
+	// TeamID is the player's team. Defenders credited on the offense's play
+	// get the opponent of the play's team, or "" if the game's teams are
+	// unknown.
+	TeamID string
+	// Line holds the counting stats the play adds; an empty line still
+	// puts the player in the game.
+	Line models.BoxScoreLine
+}
+
+// Credits attributes a play of game to its participants by role.
+func Credits(game models.Game, play models.Play) []Credit {
+	if len(play.Participants) == 0 {
+		return nil
+	}
+
+	playType := strings.ToLower(play.Type)
+	playText := strings.ToLower(play.Text)
+	made := strings.Contains(playText, "makes")
+
+	defense := opponent(game, play.TeamID)
+
+	var credits []Credit
+	credit := func(playerID, teamID string, line models.BoxScoreLine) {
+		if playerID != "" {
+			credits = append(credits, Credit{PlayerID: playerID, TeamID: teamID, Line: line})
+		}
+	}
+
+	switch {
+	case strings.Contains(playType, "freethrow"):
+		line := models.BoxScoreLine{FTA: 1}
+		if made {
+			line.FTM, line.Points = 1, 1
+		}
+		credit(play.Athlete(models.RoleShooter), play.TeamID, line)
+
+	case isFieldGoal(playType):
+		line := models.BoxScoreLine{FGA: 1}
+		three := strings.Contains(playText, "three point")
+		if three {
+			line.ThreePA = 1
+		}
+		if made {
+			line.FGM, line.Points = 1, play.ScoreValue
+			if three {
+				line.ThreePM = 1
+			}
+		}
+		credit(play.Athlete(models.RoleShooter), play.TeamID, line)
+		credit(play.Athlete(models.RoleAssister), play.TeamID, models.BoxScoreLine{Assists: 1})
+		credit(play.Athlete(models.RoleBlocker), defense, models.BoxScoreLine{Blocks: 1})
+
+	case strings.Contains(playType, "rebound"):
+		credit(play.Athlete(models.RoleRebounder), play.TeamID, models.BoxScoreLine{Rebounds: 1})
+
+	case strings.Contains(playType, "block"):
+		credit(play.Athlete(models.RoleBlocker), play.TeamID, models.BoxScoreLine{Blocks: 1})
+
+	case strings.Contains(playType, "steal"):
+		credit(play.Athlete(models.RoleStealer), play.TeamID, models.BoxScoreLine{Steals: 1})
+
+	case strings.Contains(playType, "turnover") || playType == "traveling":
+		credit(play.Athlete(models.RoleTurnover), play.TeamID, models.BoxScoreLine{Turnovers: 1})
+		credit(play.Athlete(models.RoleStealer), defense, models.BoxScoreLine{Steals: 1})
+
+	case strings.Contains(playType, "foul"):
+		credit(play.Athlete(models.RoleFouler), play.TeamID, models.BoxScoreLine{Fouls: 1})
+
+	default:
+		credit(play.Participants[0].PlayerID, play.TeamID, models.BoxScoreLine{})
+	}
+	return credits
+}
+
+// opponent returns the team facing teamID in game, or "" if teamID is not
+// one of its teams.
+func opponent(game models.Game, teamID string) string {
+	switch teamID {
+	case "":
+		return ""
+	case game.HomeTeamID:
+		return game.AwayTeamID
+	case game.AwayTeamID:
+		return game.HomeTeamID
 	}
 	return ""
 }
+
+// isFieldGoal matches the shot types (JumpShot, LayUpShot, DunkShot,
+// TipShot and the like) but not "Block Shot", the defender's play.
+func isFieldGoal(playType string) bool {
+	return strings.HasSuffix(playType, "shot") && !strings.Contains(playType, "block")
+}
@@ -0,0 +1,352 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
+)
+
+// game is the fixture matchup, Purdue (2509) at Michigan State (127).
+var game = models.Game{ID: "401822893", HomeTeamID: "127", AwayTeamID: "2509"}
+
+// play builds a play for team 127 with participants tagged by role, as the
+// ESPN source tags them.
+func play(playType, text string, scoreValue int, roles ...string) models.Play {
+	p := models.Play{GameID: "401822893", Type: playType, Text: text, ScoreValue: scoreValue, TeamID: "127"}
+	ids := []string{"1", "2"}
+	for i, role := range roles {
+		p.Participants = append(p.Participants, models.PlayParticipant{PlayerID: ids[i], Role: role})
+	}
+	return p
+}
+
+func TestCredits(t *testing.T) {
+	tests := []struct {
+		name string
+		play models.Play
+		want []Credit
+	}{
+		{
+			name: "made assisted three",
+			play: play("JumpShot", "Jaxon Okafor makes three point jumper. Assisted by Devin Marsh.", 3,
+				models.RoleShooter, models.RoleAssister),
+			want: []Credit{
+				{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{FGA: 1, FGM: 1, ThreePA: 1, ThreePM: 1, Points: 3}},
+				{PlayerID: "2", TeamID: "127", Line: models.BoxScoreLine{Assists: 1}},
+			},
+		},
+		{
+			name: "missed three",
+			play: play("JumpShot", "Owen Holloway misses three point jumper.", 0, models.RoleShooter),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{FGA: 1, ThreePA: 1}}},
+		},
+		{
+			name: "made two point jumper",
+			play: play("JumpShot", "Devin Marsh makes two point jumper.", 2, models.RoleShooter),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{FGA: 1, FGM: 1, Points: 2}}},
+		},
+		{
+			name: "blocked layup",
+			play: play("LayUpShot", "Jaxon Okafor misses layup. Blocked by Noah Pruitt.", 0,
+				models.RoleShooter, models.RoleBlocker),
+			want: []Credit{
+				{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{FGA: 1}},
+				{PlayerID: "2", TeamID: "2509", Line: models.BoxScoreLine{Blocks: 1}},
+			},
+		},
+		{
+			name: "assisted dunk",
+			play: play("DunkShot", "Carter Delacroix makes dunk. Assisted by Owen Holloway.", 2,
+				models.RoleShooter, models.RoleAssister),
+			want: []Credit{
+				{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{FGA: 1, FGM: 1, Points: 2}},
+				{PlayerID: "2", TeamID: "127", Line: models.BoxScoreLine{Assists: 1}},
+			},
+		},
+		{
+			name: "missed tip shot",
+			play: play("TipShot", "Devin Delacroix misses tip shot.", 0, models.RoleShooter),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{FGA: 1}}},
+		},
+		{
+			name: "made free throw",
+			play: play("MadeFreeThrow", "Marcus Quade makes free throw 1 of 2.", 1, models.RoleShooter),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{FTA: 1, FTM: 1, Points: 1}}},
+		},
+		{
+			name: "missed free throw",
+			play: play("MissedFreeThrow", "Marcus Quade misses free throw 2 of 2.", 0, models.RoleShooter),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{FTA: 1}}},
+		},
+		{
+			name: "offensive rebound",
+			play: play("Offensive Rebound", "Elijah Ridley offensive rebound.", 0, models.RoleRebounder),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Rebounds: 1}}},
+		},
+		{
+			name: "defensive rebound",
+			play: play("Defensive Rebound", "Malik Sandoval defensive rebound.", 0, models.RoleRebounder),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Rebounds: 1}}},
+		},
+		{
+			name: "team dead ball rebound",
+			play: play("Dead Ball Rebound", "Purdue deadball team rebound.", 0),
+		},
+		{
+			name: "block",
+			play: play("Block Shot", "Noah Pruitt block.", 0, models.RoleBlocker),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Blocks: 1}}},
+		},
+		{
+			name: "steal",
+			play: play("Steal", "Owen Holloway steal.", 0, models.RoleStealer),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Steals: 1}}},
+		},
+		{
+			name: "lost ball turnover with steal",
+			play: play("Lost Ball Turnover", "Jaxon Okafor lost ball turnover. Stolen by Noah Pruitt.", 0,
+				models.RoleTurnover, models.RoleStealer),
+			want: []Credit{
+				{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Turnovers: 1}},
+				{PlayerID: "2", TeamID: "2509", Line: models.BoxScoreLine{Steals: 1}},
+			},
+		},
+		{
+			name: "bad pass turnover",
+			play: play("Bad Pass Turnover", "Devin Castellano bad pass turnover.", 0, models.RoleTurnover),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Turnovers: 1}}},
+		},
+		{
+			name: "traveling",
+			play: play("Traveling", "Jaxon Okafor traveling.", 0, models.RoleTurnover),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Turnovers: 1}}},
+		},
+		{
+			name: "turnover",
+			play: play("Turnover", "Jaxon Okafor turnover.", 0, models.RoleTurnover),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Turnovers: 1}}},
+		},
+		{
+			name: "personal foul",
+			play: play("PersonalFoul", "Foul on Carter Fairbanks.", 0, models.RoleFouler, models.RoleFouled),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Fouls: 1}}},
+		},
+		{
+			name: "technical foul",
+			play: play("Technical Foul", "Technical foul on Carter Fairbanks.", 0, models.RoleFouler),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Fouls: 1}}},
+		},
+		{
+			name: "flagrant foul",
+			play: play("Flagrant Foul", "Flagrant foul on Carter Fairbanks.", 0, models.RoleFouler, models.RoleFouled),
+			want: []Credit{{PlayerID: "1", TeamID: "127", Line: models.BoxScoreLine{Fouls: 1}}},
+		},
+		{
+			name: "substitution",
+			play: play("Substitution", "Owen Holloway subbing in for Michigan State.", 0, models.RoleSubstitute),
+			want: []Credit{{PlayerID: "1", TeamID: "127"}},
+		},
+		{
+			name: "jump ball",
+			play: play("Jumpball", "Jump ball won by Purdue.", 0),
+		},
+		{
+			name: "tv timeout",
+			play: play("OfficialTVTimeOut", "Official TV Timeout", 0),
+		},
+		{
+			name: "short timeout",
+			play: play("ShortTimeOut", "Michigan State Timeout", 0),
+		},
+		{
+			name: "regular timeout",
+			play: play("RegularTimeOut", "Michigan State Full Timeout", 0),
+		},
+		{
+			name: "end of period",
+			play: play("End Period", "End of 1st half", 0),
+		},
+		{
+			name: "end of game",
+			play: play("End Game", "End of Game", 0),
+		},
+	}
+
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		covered[tt.play.Type] = true
+		t.Run(tt.name, func(t *testing.T) {
+			got := Credits(game, tt.play)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	for playType := range espn.KnownPlayTypes {
+		if !covered[playType] {
+			t.Errorf("no test for play type %q", playType)
+		}
+	}
+}
+
+func TestCalculatePlayerStats(t *testing.T) {
+	plays := []models.Play{
+		play("JumpShot", "Jaxon Okafor makes three point jumper. Assisted by Devin Marsh.", 3,
+			models.RoleShooter, models.RoleAssister),
+		play("TipShot", "Jaxon Okafor misses tip shot.", 0, models.RoleShooter),
+		play("MadeFreeThrow", "Jaxon Okafor makes free throw 1 of 2.", 1, models.RoleShooter),
+		play("MissedFreeThrow", "Jaxon Okafor misses free throw 2 of 2.", 0, models.RoleShooter),
+	}
+	// The blocker only appears on the offense's play.
+	blocked := play("LayUpShot", "Devin Marsh misses layup. Blocked by Noah Pruitt.", 0,
+		models.RoleShooter, models.RoleBlocker)
+	blocked.Participants[0].PlayerID = "2"
+	blocked.Participants[1].PlayerID = "3"
+	plays = append(plays, blocked)
+
+	stats := CalculatePlayerStats(game, plays)
+
+	shooter := stats["1"]
+	if shooter.Points != 4 || shooter.FGM != 1 || shooter.FGA != 2 || shooter.ThreePM != 1 || shooter.ThreePA != 1 ||
+		shooter.FTM != 1 || shooter.FTA != 2 || shooter.Assists != 0 {
+		t.Errorf("shooter: unexpected line %+v", shooter)
+	}
+	if shooter.FGPct != 50 || shooter.ThreePct != 100 || shooter.FTPct != 50 {
+		t.Errorf("shooter: unexpected percentages %+v", shooter)
+	}
+
+	assister := stats["2"]
+	if assister.Assists != 1 || assister.FGA != 1 || assister.Points != 0 {
+		t.Errorf("assister: unexpected line %+v", assister)
+	}
+
+	blocker := stats["3"]
+	if blocker.Blocks != 1 || blocker.TeamID != "2509" {
+		t.Errorf("blocker: unexpected line %+v", blocker)
+	}
+}
+
+func TestCreditsFixture(t *testing.T) {
+	summary, err := espntest.LoadSummary(espntest.League, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+	data, err := source.FromSummary(espn.MensCollegeBasketball, summary)
+	if err != nil {
+		t.Fatalf("converting fixture: %v", err)
+	}
+	plays := make(map[string]models.Play, len(data.Plays))
+	for _, p := range data.Plays {
+		plays[p.ID] = p
+	}
+
+	// Michigan State (127) hosts Purdue (2509).
+	tests := []struct {
+		id   string
+		want []Credit
+	}{
+		{"401822893000103", nil}, // Jump ball won by Purdue.
+		{"401822893000105", []Credit{ // Trey Ambrose makes layup.
+			{PlayerID: "4433648", TeamID: "2509", Line: models.BoxScoreLine{FGA: 1, FGM: 1, Points: 2}},
+		}},
+		{"401822893000109", []Credit{ // Owen Holloway misses three point jumper.
+			{PlayerID: "4433285", TeamID: "127", Line: models.BoxScoreLine{FGA: 1, ThreePA: 1}},
+		}},
+		{"401822893000111", []Credit{ // Elijah Ridley offensive rebound.
+			{PlayerID: "4433248", TeamID: "127", Line: models.BoxScoreLine{Rebounds: 1}},
+		}},
+		{"401822893000117", []Credit{ // Devin Castellano makes layup. Assisted by Owen Holloway.
+			{PlayerID: "4433137", TeamID: "127", Line: models.BoxScoreLine{FGA: 1, FGM: 1, Points: 2}},
+			{PlayerID: "4433285", TeamID: "127", Line: models.BoxScoreLine{Assists: 1}},
+		}},
+		{"401822893000121", []Credit{ // Devin Delacroix lost ball turnover.
+			{PlayerID: "4433537", TeamID: "2509", Line: models.BoxScoreLine{Turnovers: 1}},
+		}},
+		{"401822893000124", []Credit{ // Owen Holloway steal.
+			{PlayerID: "4433285", TeamID: "127", Line: models.BoxScoreLine{Steals: 1}},
+		}},
+		{"401822893000125", []Credit{ // Foul on Carter Fairbanks.
+			{PlayerID: "4433796", TeamID: "2509", Line: models.BoxScoreLine{Fouls: 1}},
+		}},
+		{"401822893000127", []Credit{ // Marcus Quade makes free throw 1 of 2.
+			{PlayerID: "4433174", TeamID: "127", Line: models.BoxScoreLine{FTA: 1, FTM: 1, Points: 1}},
+		}},
+		{"401822893000129", []Credit{ // Marcus Quade misses free throw 2 of 2, typed MadeFreeThrow.
+			{PlayerID: "4433174", TeamID: "127", Line: models.BoxScoreLine{FTA: 1}},
+		}},
+		{"401822893000132", []Credit{ // Malik Sandoval defensive rebound.
+			{PlayerID: "4433500", TeamID: "2509", Line: models.BoxScoreLine{Rebounds: 1}},
+		}},
+		{"401822893000146", []Credit{ // Cam Ridley makes two point jumper. Assisted by Isaiah Kessler.
+			{PlayerID: "4433759", TeamID: "2509", Line: models.BoxScoreLine{FGA: 1, FGM: 1, Points: 2}},
+			{PlayerID: "4433611", TeamID: "2509", Line: models.BoxScoreLine{Assists: 1}},
+		}},
+		{"401822893000149", []Credit{ // Devin Castellano bad pass turnover.
+			{PlayerID: "4433137", TeamID: "127", Line: models.BoxScoreLine{Turnovers: 1}},
+		}},
+		{"401822893000151", []Credit{ // Andre Brennan misses two point jumper. Blocked by Trey Nwosu.
+			{PlayerID: "4433685", TeamID: "2509", Line: models.BoxScoreLine{FGA: 1}},
+			{PlayerID: "4433322", TeamID: "127", Line: models.BoxScoreLine{Blocks: 1}},
+		}},
+		{"401822893000155", []Credit{ // Malik Sandoval makes three point jumper.
+			{PlayerID: "4433500", TeamID: "2509", Line: models.BoxScoreLine{FGA: 1, FGM: 1, ThreePA: 1, ThreePM: 1, Points: 3}},
+		}},
+		{"401822893000167", nil}, // Official TV Timeout
+		{"401822893000197", []Credit{ // Devin Delacroix misses layup.
+			{PlayerID: "4433537", TeamID: "2509", Line: models.BoxScoreLine{FGA: 1}},
+		}},
+		{"401822893000299", []Credit{ // Carter Delacroix makes dunk. Assisted by Owen Holloway.
+			{PlayerID: "4433100", TeamID: "127", Line: models.BoxScoreLine{FGA: 1, FGM: 1, Points: 2}},
+			{PlayerID: "4433285", TeamID: "127", Line: models.BoxScoreLine{Assists: 1}},
+		}},
+		{"401822893000309", []Credit{ // Devin Delacroix misses tip shot.
+			{PlayerID: "4433537", TeamID: "2509", Line: models.BoxScoreLine{FGA: 1}},
+		}},
+		{"401822893000387", nil}, // End of 1st half
+		{"401822893000390", []Credit{ // Owen Holloway subbing in for Michigan State.
+			{PlayerID: "4433285", TeamID: "127"},
+		}},
+		{"401822893000405", nil}, // Purdue Timeout
+		{"401822893000733", nil}, // End of Game
+	}
+
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		play, ok := plays[tt.id]
+		if !ok {
+			t.Fatalf("fixture has no play %s", tt.id)
+		}
+		covered[play.Type] = true
+		t.Run(play.Type+"/"+tt.id, func(t *testing.T) {
+			got := Credits(data.Game, play)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: expected %+v, got %+v", play.Text, tt.want, got)
+			}
+		})
+	}
+
+	// Every play type the fixture records has its credits checked on a
+	// recorded play, not only on a hand-written one.
+	for _, p := range data.Plays {
+		if !covered[p.Type] {
+			covered[p.Type] = true
+			t.Errorf("no fixture play checked for play type %q", p.Type)
+		}
+	}
+
+	// Every point on the scoreboard is credited to a player of the team
+	// that scored it.
+	points := make(map[string]int)
+	for _, s := range CalculatePlayerStats(data.Game, data.Plays) {
+		points[s.TeamID] += s.Points
+	}
+	if points[data.Game.HomeTeamID] != data.Game.HomeScore || points[data.Game.AwayTeamID] != data.Game.AwayScore {
+		t.Errorf("expected %d-%d credited, got %d-%d", data.Game.HomeScore, data.Game.AwayScore,
+			points[data.Game.HomeTeamID], points[data.Game.AwayTeamID])
+	}
+}
@@ -1,14 +1,16 @@
 package analyzer
 
 import (
-	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
 )
 
 type ZoneStats struct {
-	GameID   string              `bson:"game_id" json:"game_id"`
-	TeamID   string              `bson:"team_id" json:"team_id"`
-	PlayerID string              `bson:"player_id,omitempty" json:"player_id,omitempty"`
-	Zones    map[string]ZoneData `bson:"zones" json:"zones"`
+	GameID   string `bson:"game_id" json:"game_id"`
+	TeamID   string `bson:"team_id" json:"team_id"`
+	PlayerID string `bson:"player_id,omitempty" json:"player_id,omitempty"`
+	// PlayerName is resolved from the players registry when served.
+	PlayerName string              `bson:"-" json:"player_name,omitempty"`
+	Zones      map[string]ZoneData `bson:"zones" json:"zones"`
 }
 
 type ZoneData struct {
@@ -39,33 +41,55 @@ func GetZone(x, y float64) string {
 	return "mid_range"
 }
 
-func CalculateZoneStats(plays []espn.Play) map[string]*ZoneStats {
-	stats := make(map[string]*ZoneStats)
+func CalculateZoneStats(plays []models.Play) map[string]*ZoneStats {
+	acc := NewZoneAccumulator()
+	acc.Add(plays)
+	return acc.Stats()
+}
+
+// ZoneAccumulator keeps running zone shooting for one game, the zone
+// counterpart of StatsAccumulator.
+type ZoneAccumulator struct {
+	stats map[string]*ZoneStats
+}
+
+func NewZoneAccumulator() *ZoneAccumulator {
+	return &ZoneAccumulator{stats: make(map[string]*ZoneStats)}
+}
+
+// Stats returns every shooter's running zone totals.
+func (a *ZoneAccumulator) Stats() map[string]*ZoneStats {
+	return a.stats
+}
+
+// Add folds plays into the running totals and returns the zone lines they
+// touched.
+func (a *ZoneAccumulator) Add(plays []models.Play) map[string]*ZoneStats {
+	touched := make(map[string]*ZoneStats)
 
 	for _, play := range plays {
-		if !play.ShootingPlay || play.Coordinate == nil {
+		if !play.ShootingPlay || play.CoordinateX == nil || play.CoordinateY == nil {
 			continue
 		}
 
-		if len(play.Participants) == 0 {
+		playerID := play.Athlete(models.RoleShooter)
+		if playerID == "" {
 			continue
 		}
-
-		playerID := play.Participants[0].Athlete.ID
-		teamID := getTeamIDFromPlay(play)
+		teamID := play.TeamID
 
 		key := playerID
-		if _, exists := stats[key]; !exists {
-			stats[key] = &ZoneStats{
-				GameID:   play.ID[:9],
+		if _, exists := a.stats[key]; !exists {
+			a.stats[key] = &ZoneStats{
+				GameID:   play.GameID,
 				TeamID:   teamID,
 				PlayerID: playerID,
 				Zones:    make(map[string]ZoneData),
 			}
 		}
 
-		zone := GetZone(play.Coordinate.X, play.Coordinate.Y)
-		zoneData := stats[key].Zones[zone]
+		zone := GetZone(*play.CoordinateX, *play.CoordinateY)
+		zoneData := a.stats[key].Zones[zone]
 		zoneData.Attempts++
 		if play.ScoringPlay {
 			zoneData.Makes++
@@ -73,15 +97,9 @@ func CalculateZoneStats(plays []espn.Play) map[string]*ZoneStats {
 		if zoneData.Attempts > 0 {
 			zoneData.Pct = float64(zoneData.Makes) / float64(zoneData.Attempts) * 100
 		}
-		stats[key].Zones[zone] = zoneData
+		a.stats[key].Zones[zone] = zoneData
+		touched[key] = a.stats[key]
 	}
 
-	return stats
-}
-
-func getTeamIDFromPlay(play espn.Play) string {
-	if play.Team != nil {
-		return play.Team.ID
-	}
-	return ""
+	return touched
 }
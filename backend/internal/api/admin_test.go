@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, tc := range []struct {
+		token, header string
+		want          int
+	}{
+		{"", "", http.StatusForbidden},
+		{"", "Bearer ", http.StatusForbidden},
+		{"s3cret", "", http.StatusUnauthorized},
+		{"s3cret", "Bearer wrong", http.StatusUnauthorized},
+		{"s3cret", "s3cret", http.StatusUnauthorized},
+		{"s3cret", "Bearer s3cret", http.StatusOK},
+	} {
+		r := httptest.NewRequest("GET", "/api/admin/ingest-errors", nil)
+		if tc.header != "" {
+			r.Header.Set("Authorization", tc.header)
+		}
+		w := httptest.NewRecorder()
+		RequireToken(tc.token)(ok).ServeHTTP(w, r)
+		if w.Code != tc.want {
+			t.Errorf("token %q, header %q: expected %d, got %d", tc.token, tc.header, tc.want, w.Code)
+		}
+	}
+}
@@ -1,13 +1,23 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"log"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
 	"github.com/asallaram/cbb-analytics/internal/storage"
 	"github.com/gorilla/mux"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type Handler struct {
@@ -20,26 +30,116 @@ func NewHandler(db *storage.MongoDB) *Handler {
 
 func (h *Handler) GetGames(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	query := r.URL.Query()
 
-	date := r.URL.Query().Get("date")
-	status := r.URL.Query().Get("status")
+	date := query.Get("date")
+	status := query.Get("status")
+	league := query.Get("league")
+	conference := query.Get("conference")
 
 	filter := bson.M{}
+	var and bson.A
+
+	if date != "" && (query.Get("from") != "" || query.Get("to") != "") {
+		http.Error(w, "use either date or from/to", http.StatusBadRequest)
+		return
+	}
 	if date != "" {
-		filter["date"] = date
+		day, err := parseGameDay(date)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter["game_day"] = day
+	}
+	if from, to := query.Get("from"), query.Get("to"); from != "" || to != "" {
+		rangeFilter := bson.M{}
+		for op, v := range map[string]string{"$gte": from, "$lte": to} {
+			if v == "" {
+				continue
+			}
+			day, err := parseGameDay(v)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rangeFilter[op] = day
+		}
+		filter["game_day"] = rangeFilter
+	}
+	if team := query.Get("team"); team != "" {
+		name := primitive.Regex{Pattern: regexp.QuoteMeta(team), Options: "i"}
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"home_team_id": team},
+			bson.M{"away_team_id": team},
+			bson.M{"home_team_name": name},
+			bson.M{"away_team_name": name},
+		}})
 	}
 	if status != "" {
 		filter["status"] = status
 	}
+	if league != "" {
+		l, err := espn.ParseLeague(league)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter["league"] = l
+	}
+	if conference != "" {
+		filter["conference_slug"] = strings.ToLower(conference)
+	}
+	if ranked := query.Get("ranked"); ranked != "" {
+		wantRanked, err := strconv.ParseBool(ranked)
+		if err != nil {
+			http.Error(w, "ranked must be true or false", http.StatusBadRequest)
+			return
+		}
+		if wantRanked {
+			and = append(and, bson.M{"$or": bson.A{
+				bson.M{"home_rank": bson.M{"$gt": 0}},
+				bson.M{"away_rank": bson.M{"$gt": 0}},
+			}})
+		} else {
+			filter["home_rank"] = bson.M{"$exists": false}
+			filter["away_rank"] = bson.M{"$exists": false}
+		}
+	}
+	if neutral := query.Get("neutral"); neutral != "" {
+		isNeutral, err := strconv.ParseBool(neutral)
+		if err != nil {
+			http.Error(w, "neutral must be true or false", http.StatusBadRequest)
+			return
+		}
+		filter["neutral_site"] = isNeutral
+	}
+	// Replays of recorded games are only listed when asked for.
+	filter["replay"] = bson.M{"$ne": true}
+	if replay := query.Get("replay"); replay != "" {
+		wantReplay, err := strconv.ParseBool(replay)
+		if err != nil {
+			http.Error(w, "replay must be true or false", http.StatusBadRequest)
+			return
+		}
+		if wantReplay {
+			filter["replay"] = true
+		}
+	}
+	if len(and) > 0 {
+		filter["$and"] = and
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "tip_time", Value: 1}})
 
-	cursor, err := h.db.DB.Collection("games").Find(ctx, filter)
+	cursor, err := h.db.DB.Collection("games").Find(ctx, filter, opts)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	defer cursor.Close(ctx)
 
-	var games []interface{}
+	games := []models.Game{}
 	if err := cursor.All(ctx, &games); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -49,11 +149,22 @@ func (h *Handler) GetGames(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(games)
 }
 
+// parseGameDay reads a date filter as either 2026-03-15 or 20260315, in
+// the same form game days are stored in.
+func parseGameDay(s string) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, "20060102"} {
+		if day, err := time.Parse(layout, s); err == nil {
+			return day, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, want YYYY-MM-DD", s)
+}
+
 func (h *Handler) GetGame(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	gameID := vars["id"]
 
-	game, err := h.db.GetGame(gameID)
+	game, err := h.db.GetGame(r.Context(), gameID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
@@ -67,34 +178,52 @@ func (h *Handler) GetPlays(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	gameID := vars["id"]
 
-	plays, err := h.db.GetPlaysByGame(gameID)
+	plays, err := h.db.GetPlaysByGame(r.Context(), gameID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	var ids []string
+	for _, play := range plays {
+		ids = append(ids, play.PlayerIDs...)
+	}
+	names := h.resolveNames(r.Context(), ids)
+	for i := range plays {
+		namePlay(&plays[i], names)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(plays)
 }
 
+// namePlay fills in the names of a play's athletes.
+func namePlay(play *models.Play, names map[string]string) {
+	for _, id := range play.PlayerIDs {
+		play.PlayerNames = append(play.PlayerNames, names[id])
+	}
+	for j := range play.Participants {
+		play.Participants[j].PlayerName = names[play.Participants[j].PlayerID]
+	}
+}
+
 func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	gameID := vars["id"]
 
-	ctx := r.Context()
-	filter := bson.M{"game_id": gameID}
-
-	cursor, err := h.db.DB.Collection("live_stats").Find(ctx, filter)
+	stats, err := h.db.GetPlayerStats(r.Context(), gameID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer cursor.Close(ctx)
 
-	var stats []interface{}
-	if err := cursor.All(ctx, &stats); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	var ids []string
+	for _, s := range stats {
+		ids = append(ids, s.PlayerID)
+	}
+	names := h.resolveNames(r.Context(), ids)
+	for i := range stats {
+		stats[i].PlayerName = names[stats[i].PlayerID]
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -105,24 +234,95 @@ func (h *Handler) GetZones(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	gameID := vars["id"]
 
-	ctx := r.Context()
-	filter := bson.M{"game_id": gameID}
+	zones, err := h.db.GetZoneStats(r.Context(), gameID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-	cursor, err := h.db.DB.Collection("zone_stats").Find(ctx, filter)
+	var ids []string
+	for _, z := range zones {
+		ids = append(ids, z.PlayerID)
+	}
+	names := h.resolveNames(r.Context(), ids)
+	for i := range zones {
+		zones[i].PlayerName = names[zones[i].PlayerID]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(zones)
+}
+
+func (h *Handler) GetBoxScore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	gameID := vars["id"]
+
+	box, err := h.db.GetBoxScore(r.Context(), gameID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(box)
+}
+
+func (h *Handler) GetDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	gameID := vars["id"]
+
+	discrepancies, err := h.db.GetDiscrepancies(r.Context(), gameID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer cursor.Close(ctx)
 
-	var zones []interface{}
-	if err := cursor.All(ctx, &zones); err != nil {
+	// Players missing from the box score were stored without a name.
+	var ids []string
+	for _, d := range discrepancies {
+		if d.PlayerName == "" {
+			ids = append(ids, d.PlayerID)
+		}
+	}
+	names := h.resolveNames(r.Context(), ids)
+	for i := range discrepancies {
+		if discrepancies[i].PlayerName == "" {
+			discrepancies[i].PlayerName = names[discrepancies[i].PlayerID]
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(discrepancies)
+}
+
+// GetPlayEdits lists the corrections ESPN has made to a game's plays.
+func (h *Handler) GetPlayEdits(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	gameID := vars["id"]
+
+	edits, err := h.db.GetPlayEdits(r.Context(), gameID)
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	var ids []string
+	for _, e := range edits {
+		ids = append(ids, e.Before.PlayerIDs...)
+		if e.After != nil {
+			ids = append(ids, e.After.PlayerIDs...)
+		}
+	}
+	names := h.resolveNames(r.Context(), ids)
+	for i := range edits {
+		namePlay(&edits[i].Before, names)
+		if edits[i].After != nil {
+			namePlay(edits[i].After, names)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(zones)
+	json.NewEncoder(w).Encode(edits)
 }
 
 func (h *Handler) GetInsights(w http.ResponseWriter, r *http.Request) {
@@ -137,12 +337,72 @@ func (h *Handler) GetInsights(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	insights, err := h.db.GetInsights(gameID, limit)
+	insights, err := h.db.GetInsights(r.Context(), gameID, limit)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	var ids []string
+	for _, insight := range insights {
+		if insight.Context.PlayerID != "" {
+			ids = append(ids, insight.Context.PlayerID)
+		}
+	}
+	names := h.resolveNames(r.Context(), ids)
+	for i := range insights {
+		if name, ok := names[insights[i].Context.PlayerID]; ok {
+			insights[i].Context.PlayerName = name
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(insights)
 }
+
+// GetIngestErrors lists the ingest error journal: games whose ingestion
+// failed part way, still open unless all=true, most recent failure first.
+func (h *Handler) GetIngestErrors(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 100
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	all := query.Get("all") == "true"
+
+	entries, err := h.db.GetIngestErrors(r.Context(), all, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// GetSchemaDrift lists how ESPN payloads have drifted from what decoding
+// expects, most recently seen first, optionally by league and kind.
+func (h *Handler) GetSchemaDrift(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	drift, err := h.db.GetSchemaDrift(r.Context(), query.Get("league"), query.Get("kind"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(drift)
+}
+
+// resolveNames looks up display names in the players registry. A registry
+// failure only costs the names, not the response.
+func (h *Handler) resolveNames(ctx context.Context, playerIDs []string) map[string]string {
+	names, err := h.db.GetPlayerNames(ctx, playerIDs)
+	if err != nil {
+		log.Printf("Error resolving player names: %v", err)
+		return map[string]string{}
+	}
+	return names
+}
@@ -0,0 +1,99 @@
+// Package archive keeps compressed copies of the raw ESPN payloads the
+// poller and backfill fetch, so games can be re-decoded after a decoding
+// rule changes. The poller prunes payloads older than ARCHIVE_RETENTION.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrNotFound = errors.New("archive: payload not found")
+
+// Entry identifies one archived payload. Key is the scoreboard date or the
+// summary's game ID.
+type Entry struct {
+	League    string    `bson:"league" json:"league"`
+	Kind      string    `bson:"kind" json:"kind"`
+	Key       string    `bson:"key" json:"key"`
+	FetchedAt time.Time `bson:"fetched_at" json:"fetched_at"`
+}
+
+type Store interface {
+	Put(ctx context.Context, e Entry, body []byte) error
+	// List returns the entries for a key, oldest first.
+	List(ctx context.Context, league, kind, key string) ([]Entry, error)
+	Get(ctx context.Context, e Entry) ([]byte, error)
+	// Prune deletes every payload fetched before cutoff and returns how
+	// many it deleted.
+	Prune(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// Latest returns the most recent payload archived under a key.
+func Latest(ctx context.Context, s Store, league, kind, key string) ([]byte, Entry, error) {
+	entries, err := s.List(ctx, league, kind, key)
+	if err != nil {
+		return nil, Entry{}, err
+	}
+	if len(entries) == 0 {
+		return nil, Entry{}, ErrNotFound
+	}
+
+	e := entries[len(entries)-1]
+	body, err := s.Get(ctx, e)
+	return body, e, err
+}
+
+// Recorder adapts a Store to espn.Recorder. Archive failures are logged and
+// never fail the fetch itself.
+type Recorder struct {
+	Store Store
+}
+
+func (r Recorder) Record(ctx context.Context, league espn.League, kind, key string, fetchedAt time.Time, body []byte) {
+	e := Entry{League: string(league), Kind: kind, Key: key, FetchedAt: fetchedAt.UTC()}
+	if err := r.Store.Put(ctx, e, body); err != nil {
+		log.Printf("Error archiving %s %s/%s: %v", kind, league, key, err)
+	}
+}
+
+func compress(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(r io.Reader) ([]byte, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// stamp is the sortable, filename-safe form of a fetch time.
+const stamp = "20060102T150405.000Z"
+
+// Open picks the local directory store when dir is set and the GridFS
+// bucket in db otherwise.
+func Open(dir string, db *mongo.Database) Store {
+	if dir != "" {
+		return Dir{Root: dir}
+	}
+	return NewGridFS(db)
+}
@@ -0,0 +1,112 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Dir archives payloads on local disk as
+// <root>/<league>/<kind>/<key>/<fetched-at>.json.gz.
+type Dir struct {
+	Root string
+}
+
+func (d Dir) Put(ctx context.Context, e Entry, body []byte) error {
+	gz, err := compress(body)
+	if err != nil {
+		return err
+	}
+
+	path := d.path(e)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write then rename so a reader never sees a half-written file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, gz, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (d Dir) List(ctx context.Context, league, kind, key string) ([]Entry, error) {
+	files, err := os.ReadDir(filepath.Join(d.Root, league, kind, key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), ".json.gz")
+		if !ok {
+			continue
+		}
+		fetchedAt, err := time.Parse(stamp, name)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, Entry{League: league, Kind: kind, Key: key, FetchedAt: fetchedAt})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].FetchedAt.Before(entries[j].FetchedAt)
+	})
+	return entries, nil
+}
+
+func (d Dir) Get(ctx context.Context, e Entry) ([]byte, error) {
+	gz, err := os.ReadFile(d.path(e))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decompress(bytes.NewReader(gz))
+}
+
+func (d Dir) Prune(ctx context.Context, cutoff time.Time) (int, error) {
+	pruned := 0
+	err := filepath.WalkDir(d.Root, func(path string, f fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		name, ok := strings.CutSuffix(f.Name(), ".json.gz")
+		if f.IsDir() || !ok {
+			return nil
+		}
+		fetchedAt, err := time.Parse(stamp, name)
+		if err != nil || !fetchedAt.Before(cutoff) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		pruned++
+		// Drop the key's directory once its last payload is gone; Remove
+		// fails harmlessly while it still has others.
+		os.Remove(filepath.Dir(path))
+		return nil
+	})
+	return pruned, err
+}
+
+func (d Dir) path(e Entry) string {
+	return filepath.Join(d.Root, e.League, e.Kind, e.Key, e.FetchedAt.UTC().Format(stamp)+".json.gz")
+}
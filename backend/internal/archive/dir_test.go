@@ -0,0 +1,86 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+)
+
+func TestDirRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := Dir{Root: t.TempDir()}
+	rec := Recorder{Store: store}
+
+	first := time.Date(2026, 3, 15, 17, 0, 0, 123456789, time.UTC)
+	second := first.Add(30 * time.Second)
+
+	rec.Record(ctx, espn.MensCollegeBasketball, espn.KindSummary, "401822894", second, []byte(`{"plays":[2]}`))
+	rec.Record(ctx, espn.MensCollegeBasketball, espn.KindSummary, "401822894", first, []byte(`{"plays":[1]}`))
+
+	entries, err := store.List(ctx, "mens-college-basketball", "summary", "401822894")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 2 || !entries[0].FetchedAt.Before(entries[1].FetchedAt) {
+		t.Fatalf("expected 2 entries oldest first, got %+v", entries)
+	}
+
+	body, e, err := Latest(ctx, store, "mens-college-basketball", "summary", "401822894")
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	if !bytes.Equal(body, []byte(`{"plays":[2]}`)) || !e.FetchedAt.Equal(second.Truncate(time.Millisecond)) {
+		t.Errorf("unexpected latest payload %s at %v", body, e.FetchedAt)
+	}
+
+	// Payloads are stored compressed, not as plain JSON.
+	raw, err := os.ReadFile(filepath.Join(store.Root, "mens-college-basketball", "summary", "401822894", "20260315T170000.123Z.json.gz"))
+	if err != nil {
+		t.Fatalf("reading archived file: %v", err)
+	}
+	if len(raw) < 2 || raw[0] != 0x1f || raw[1] != 0x8b {
+		t.Errorf("expected gzip data, got %q", raw)
+	}
+
+	if _, _, err := Latest(ctx, store, "mens-college-basketball", "summary", "401000000"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown game, got %v", err)
+	}
+}
+
+func TestDirPrune(t *testing.T) {
+	ctx := context.Background()
+	store := Dir{Root: t.TempDir()}
+
+	cutoff := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	old := Entry{League: "mens-college-basketball", Kind: "summary", Key: "401822880", FetchedAt: cutoff.Add(-time.Hour)}
+	kept := Entry{League: "mens-college-basketball", Kind: "summary", Key: "401822894", FetchedAt: cutoff}
+	for _, e := range []Entry{old, kept} {
+		if err := store.Put(ctx, e, []byte(`{}`)); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+
+	pruned, err := store.Prune(ctx, cutoff)
+	if err != nil || pruned != 1 {
+		t.Fatalf("expected 1 payload pruned, got %d, %v", pruned, err)
+	}
+	if _, err := store.Get(ctx, old); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the old payload to be gone, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(store.path(old))); !os.IsNotExist(err) {
+		t.Errorf("expected the emptied key directory to be removed, got %v", err)
+	}
+	if _, err := store.Get(ctx, kept); err != nil {
+		t.Errorf("expected the payload at the cutoff to be kept, got %v", err)
+	}
+
+	if pruned, err := (Dir{Root: filepath.Join(t.TempDir(), "missing")}).Prune(ctx, cutoff); err != nil || pruned != 0 {
+		t.Errorf("expected a missing archive to prune nothing, got %d, %v", pruned, err)
+	}
+}
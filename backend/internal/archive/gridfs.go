@@ -0,0 +1,138 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFS archives payloads in a Mongo GridFS bucket, one file per fetch with
+// the Entry stored as file metadata.
+type GridFS struct {
+	DB     *mongo.Database
+	Bucket string
+}
+
+func NewGridFS(db *mongo.Database) *GridFS {
+	return &GridFS{DB: db, Bucket: "raw_payloads"}
+}
+
+// bucket returns a fresh handle per call: GridFS deadlines are set on the
+// bucket, so sharing one across goroutines would race.
+func (g *GridFS) bucket(ctx context.Context) (*gridfs.Bucket, error) {
+	b, err := gridfs.NewBucket(g.DB, options.GridFSBucket().SetName(g.Bucket))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		b.SetWriteDeadline(deadline)
+		b.SetReadDeadline(deadline)
+	}
+	return b, nil
+}
+
+func (g *GridFS) Put(ctx context.Context, e Entry, body []byte) error {
+	gz, err := compress(body)
+	if err != nil {
+		return err
+	}
+
+	b, err := g.bucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := options.GridFSUpload().SetMetadata(e)
+	_, err = b.UploadFromStream(filename(e), bytes.NewReader(gz), opts)
+	return err
+}
+
+func (g *GridFS) List(ctx context.Context, league, kind, key string) ([]Entry, error) {
+	b, err := g.bucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		"metadata.league": league,
+		"metadata.kind":   kind,
+		"metadata.key":    key,
+	}
+	opts := options.GridFSFind().SetSort(bson.M{"metadata.fetched_at": 1})
+
+	cursor, err := b.FindContext(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []struct {
+		Metadata Entry `bson:"metadata"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(files))
+	for _, f := range files {
+		entries = append(entries, f.Metadata)
+	}
+	return entries, nil
+}
+
+func (g *GridFS) Get(ctx context.Context, e Entry) ([]byte, error) {
+	b, err := g.bucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	_, err = b.DownloadToStreamByName(filename(e), &buf)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decompress(&buf)
+}
+
+func (g *GridFS) Prune(ctx context.Context, cutoff time.Time) (int, error) {
+	b, err := g.bucket(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	cursor, err := b.FindContext(ctx, bson.M{"metadata.fetched_at": bson.M{"$lt": cutoff}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []struct {
+		ID any `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for _, f := range files {
+		if err := b.DeleteContext(ctx, f.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+func filename(e Entry) string {
+	return fmt.Sprintf("%s/%s/%s/%s.json.gz", e.League, e.Kind, e.Key, e.FetchedAt.UTC().Format(stamp))
+}
@@ -0,0 +1,304 @@
+// Package config loads the settings shared by the API server, the poller
+// and the batch commands. Each setting has a default, which a .env-style
+// settings file overrides, which the environment overrides in turn.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/analyzer"
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/joho/godotenv"
+)
+
+// DefaultFile is read, if it exists, when no settings file is named.
+const DefaultFile = ".env"
+
+type Config struct {
+	MongoURI    string
+	Database    string
+	ESPNBaseURL string
+	Leagues     []espn.League
+	DataDir     string
+
+	// ArchivePayloads keeps a copy of every fetched ESPN payload, in
+	// ArchiveDir or, if that is empty, a GridFS bucket. The poller prunes
+	// payloads older than ArchiveRetention; zero keeps them forever.
+	ArchivePayloads  bool
+	ArchiveDir       string
+	ArchiveRetention time.Duration
+
+	// API server. The /api/admin endpoints require AdminToken as a bearer
+	// token and are disabled while it is empty.
+	Port        int
+	CORSOrigins []string
+	AdminToken  string
+
+	// Poller.
+	PollerID      string
+	PollerWorkers int
+	HealthAddr    string
+	LiveInterval  time.Duration
+
+	Insights analyzer.Thresholds
+}
+
+func Default() *Config {
+	return &Config{
+		MongoURI:        "mongodb://localhost:27017",
+		Database:        "cbb_analytics",
+		ESPNBaseURL:     "https://site.api.espn.com",
+		Leagues:         []espn.League{espn.MensCollegeBasketball},
+		ArchivePayloads: true,
+		Port:            8080,
+		CORSOrigins:     []string{"*"},
+		PollerWorkers:   8,
+		HealthAddr:      ":8082",
+		LiveInterval:    30 * time.Second,
+		Insights:        analyzer.DefaultThresholds,
+	}
+}
+
+// Load reads the settings file at path, or DefaultFile if path is empty and
+// that file exists, applies the environment on top and validates the result.
+func Load(path string) (*Config, error) {
+	file := map[string]string{}
+	if path == "" {
+		if _, err := os.Stat(DefaultFile); err == nil {
+			path = DefaultFile
+		}
+	}
+	if path != "" {
+		var err error
+		if file, err = godotenv.Read(path); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+	}
+
+	cfg := Default()
+	var errs []error
+	for _, s := range settings {
+		v, ok := os.LookupEnv(s.key)
+		if !ok {
+			v, ok = file[s.key]
+		}
+		if !ok {
+			continue
+		}
+		if err := s.set(cfg, strings.TrimSpace(v)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.key, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate reports every setting that is out of range, not just the first.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(strings.HasPrefix(c.MongoURI, "mongodb://") || strings.HasPrefix(c.MongoURI, "mongodb+srv://"),
+		"MONGO_URI", "must be a mongodb:// or mongodb+srv:// URI")
+	check(c.Database != "", "MONGO_DATABASE", "must not be empty")
+	u, err := url.Parse(c.ESPNBaseURL)
+	check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+		"ESPN_BASE_URL", "must be an http(s) URL, got %q", c.ESPNBaseURL)
+	check(len(c.Leagues) > 0, "LEAGUES", "must name at least one league")
+
+	check(c.ArchiveRetention >= 0, "ARCHIVE_RETENTION", "must not be negative, got %v", c.ArchiveRetention)
+
+	check(c.Port > 0 && c.Port < 65536, "PORT", "must be a TCP port, got %d", c.Port)
+	check(len(c.CORSOrigins) > 0, "CORS_ORIGINS", "must allow at least one origin")
+
+	check(c.PollerWorkers >= 1, "POLLER_WORKERS", "must be at least 1, got %d", c.PollerWorkers)
+	check(c.HealthAddr != "", "HEALTH_ADDR", "must not be empty")
+	check(c.LiveInterval >= 10*time.Second && c.LiveInterval <= 5*time.Minute,
+		"POLL_LIVE_INTERVAL", "must be between 10s and 5m, got %v", c.LiveInterval)
+
+	t := c.Insights
+	for _, p := range []struct {
+		key string
+		pct float64
+	}{
+		{"INSIGHT_HOT_FG_PCT", t.HotFGPct},
+		{"INSIGHT_COLD_FG_PCT", t.ColdFGPct},
+		{"INSIGHT_HOT_THREE_PCT", t.HotThreePct},
+		{"INSIGHT_ZONE_HOT_PCT", t.ZoneHotPct},
+	} {
+		check(p.pct >= 0 && p.pct <= 100, p.key, "must be a percentage, got %v", p.pct)
+	}
+	for _, n := range []struct {
+		key string
+		n   int
+	}{
+		{"INSIGHT_MIN_FGA", t.MinFGA},
+		{"INSIGHT_COLD_MIN_FGA", t.ColdMinFGA},
+		{"INSIGHT_HOT_THREE_PA", t.HotThreePA},
+		{"INSIGHT_ZONE_MIN_ATTEMPTS", t.ZoneMinAttempts},
+		{"INSIGHT_MIN_MOMENTUM_PLAYS", t.MinMomentumPlays},
+		{"INSIGHT_RUN_POINTS", t.RunPoints},
+		{"INSIGHT_TURNOVER_TROUBLE", t.TurnoverTrouble},
+		{"INSIGHT_FOUL_TROUBLE", t.FoulTrouble},
+	} {
+		check(n.n >= 1, n.key, "must be at least 1, got %d", n.n)
+	}
+	check(t.ColdFGPct < t.HotFGPct, "INSIGHT_COLD_FG_PCT", "must be below INSIGHT_HOT_FG_PCT")
+
+	return errors.Join(errs...)
+}
+
+// Dump renders the effective settings in the settings file format, with any
+// password in MONGO_URI and the admin token masked.
+func (c *Config) Dump() string {
+	var b strings.Builder
+	for _, s := range settings {
+		v := s.get(c)
+		if s.key == "MONGO_URI" {
+			v = redactURI(v)
+		}
+		if s.key == "ADMIN_TOKEN" && v != "" {
+			v = "xxxxx"
+		}
+		fmt.Fprintf(&b, "%s=%s\n", s.key, v)
+	}
+	return b.String()
+}
+
+func redactURI(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), "xxxxx")
+	}
+	return u.String()
+}
+
+// setting binds one key to a field of Config.
+type setting struct {
+	key string
+	set func(c *Config, v string) error
+	get func(c *Config) string
+}
+
+var settings = []setting{
+	str("MONGO_URI", func(c *Config) *string { return &c.MongoURI }),
+	str("MONGO_DATABASE", func(c *Config) *string { return &c.Database }),
+	str("ESPN_BASE_URL", func(c *Config) *string { return &c.ESPNBaseURL }),
+	{
+		key: "LEAGUES",
+		set: func(c *Config, v string) (err error) {
+			c.Leagues, err = espn.ParseLeagues(v)
+			return err
+		},
+		get: func(c *Config) string {
+			names := make([]string, len(c.Leagues))
+			for i, l := range c.Leagues {
+				names[i] = string(l)
+			}
+			return strings.Join(names, ",")
+		},
+	},
+	str("DATA_DIR", func(c *Config) *string { return &c.DataDir }),
+	boolean("ARCHIVE_PAYLOADS", func(c *Config) *bool { return &c.ArchivePayloads }),
+	str("ARCHIVE_DIR", func(c *Config) *string { return &c.ArchiveDir }),
+	duration("ARCHIVE_RETENTION", func(c *Config) *time.Duration { return &c.ArchiveRetention }),
+
+	integer("PORT", func(c *Config) *int { return &c.Port }),
+	list("CORS_ORIGINS", func(c *Config) *[]string { return &c.CORSOrigins }),
+	str("ADMIN_TOKEN", func(c *Config) *string { return &c.AdminToken }),
+
+	str("POLLER_ID", func(c *Config) *string { return &c.PollerID }),
+	integer("POLLER_WORKERS", func(c *Config) *int { return &c.PollerWorkers }),
+	str("HEALTH_ADDR", func(c *Config) *string { return &c.HealthAddr }),
+	duration("POLL_LIVE_INTERVAL", func(c *Config) *time.Duration { return &c.LiveInterval }),
+
+	integer("INSIGHT_MIN_FGA", func(c *Config) *int { return &c.Insights.MinFGA }),
+	float("INSIGHT_HOT_FG_PCT", func(c *Config) *float64 { return &c.Insights.HotFGPct }),
+	integer("INSIGHT_COLD_MIN_FGA", func(c *Config) *int { return &c.Insights.ColdMinFGA }),
+	float("INSIGHT_COLD_FG_PCT", func(c *Config) *float64 { return &c.Insights.ColdFGPct }),
+	integer("INSIGHT_HOT_THREE_PA", func(c *Config) *int { return &c.Insights.HotThreePA }),
+	float("INSIGHT_HOT_THREE_PCT", func(c *Config) *float64 { return &c.Insights.HotThreePct }),
+	integer("INSIGHT_ZONE_MIN_ATTEMPTS", func(c *Config) *int { return &c.Insights.ZoneMinAttempts }),
+	float("INSIGHT_ZONE_HOT_PCT", func(c *Config) *float64 { return &c.Insights.ZoneHotPct }),
+	integer("INSIGHT_MIN_MOMENTUM_PLAYS", func(c *Config) *int { return &c.Insights.MinMomentumPlays }),
+	integer("INSIGHT_RUN_POINTS", func(c *Config) *int { return &c.Insights.RunPoints }),
+	integer("INSIGHT_TURNOVER_TROUBLE", func(c *Config) *int { return &c.Insights.TurnoverTrouble }),
+	integer("INSIGHT_FOUL_TROUBLE", func(c *Config) *int { return &c.Insights.FoulTrouble }),
+}
+
+func str(key string, field func(*Config) *string) setting {
+	return setting{
+		key: key,
+		set: func(c *Config, v string) error { *field(c) = v; return nil },
+		get: func(c *Config) string { return *field(c) },
+	}
+}
+
+func integer(key string, field func(*Config) *int) setting {
+	return setting{
+		key: key,
+		set: func(c *Config, v string) (err error) { *field(c), err = strconv.Atoi(v); return err },
+		get: func(c *Config) string { return strconv.Itoa(*field(c)) },
+	}
+}
+
+func float(key string, field func(*Config) *float64) setting {
+	return setting{
+		key: key,
+		set: func(c *Config, v string) (err error) { *field(c), err = strconv.ParseFloat(v, 64); return err },
+		get: func(c *Config) string { return strconv.FormatFloat(*field(c), 'g', -1, 64) },
+	}
+}
+
+func boolean(key string, field func(*Config) *bool) setting {
+	return setting{
+		key: key,
+		set: func(c *Config, v string) (err error) { *field(c), err = strconv.ParseBool(v); return err },
+		get: func(c *Config) string { return strconv.FormatBool(*field(c)) },
+	}
+}
+
+func duration(key string, field func(*Config) *time.Duration) setting {
+	return setting{
+		key: key,
+		set: func(c *Config, v string) (err error) { *field(c), err = time.ParseDuration(v); return err },
+		get: func(c *Config) string { return field(c).String() },
+	}
+}
+
+// list reads a comma-separated list, ignoring blanks.
+func list(key string, field func(*Config) *[]string) setting {
+	return setting{
+		key: key,
+		set: func(c *Config, v string) error {
+			var items []string
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			*field(c) = items
+			return nil
+		},
+		get: func(c *Config) string { return strings.Join(*field(c), ",") },
+	}
+}
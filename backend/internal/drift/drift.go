@@ -0,0 +1,84 @@
+// Package drift records the schema drift the ESPN client finds in what it
+// fetches: a warning in the log the first time this process sees each
+// finding, and a running tally in Mongo for /api/admin/schema-drift.
+package drift
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// Store is where the tallies are kept.
+type Store interface {
+	RecordSchemaDrift(ctx context.Context, findings []models.SchemaDrift) error
+}
+
+// Recorder adapts a Store to espn.DriftRecorder.
+type Recorder struct {
+	store Store
+
+	mu     sync.Mutex
+	warned map[string]bool
+}
+
+func NewRecorder(store Store) *Recorder {
+	return &Recorder{store: store, warned: make(map[string]bool)}
+}
+
+func (r *Recorder) RecordDrift(ctx context.Context, league espn.League, kind, key string, seenAt time.Time, drift espn.Drift) {
+	found := Findings(league, kind, key, seenAt, drift)
+	r.warn(found)
+
+	if err := r.store.RecordSchemaDrift(ctx, found); err != nil {
+		log.Printf("Error recording schema drift in %s %s: %v", kind, key, err)
+	}
+}
+
+// warn logs the findings this process has not warned about yet.
+func (r *Recorder) warn(found []models.SchemaDrift) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, f := range found {
+		id := f.League + "/" + f.Payload + "/" + f.Kind + "/" + f.Name
+		if r.warned[id] {
+			continue
+		}
+		r.warned[id] = true
+		log.Printf("⚠️  ESPN schema drift in %s %s %s: %s %q", f.League, f.Payload, f.LastKey, f.Kind, f.Name)
+	}
+}
+
+// Findings splits one payload's drift into a tally per field or play type.
+func Findings(league espn.League, kind, key string, seenAt time.Time, drift espn.Drift) []models.SchemaDrift {
+	finding := func(driftKind, name string, objects int) models.SchemaDrift {
+		return models.SchemaDrift{
+			League:    string(league),
+			Payload:   kind,
+			Kind:      driftKind,
+			Name:      name,
+			Fetches:   1,
+			Objects:   objects,
+			FirstSeen: seenAt,
+			LastSeen:  seenAt,
+			LastKey:   key,
+		}
+	}
+
+	var found []models.SchemaDrift
+	for _, name := range drift.Unknown {
+		found = append(found, finding(models.DriftUnknownField, name, 0))
+	}
+	for name, n := range drift.Missing {
+		found = append(found, finding(models.DriftMissingField, name, n))
+	}
+	for _, name := range drift.PlayTypes {
+		found = append(found, finding(models.DriftPlayType, name, 0))
+	}
+	return found
+}
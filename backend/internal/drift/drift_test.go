@@ -0,0 +1,59 @@
+package drift
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+type memStore struct {
+	findings []models.SchemaDrift
+}
+
+func (s *memStore) RecordSchemaDrift(ctx context.Context, findings []models.SchemaDrift) error {
+	s.findings = append(s.findings, findings...)
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	var logs bytes.Buffer
+	prev := log.Writer()
+	log.SetOutput(&logs)
+	defer log.SetOutput(prev)
+
+	store := &memStore{}
+	r := NewRecorder(store)
+
+	d := espn.Drift{
+		Unknown:   []string{"plays[].coordinates"},
+		Missing:   map[string]int{"plays[].coordinate": 40},
+		PlayTypes: []string{"Hook Shot"},
+	}
+	seen := time.Date(2026, 3, 15, 17, 0, 0, 0, time.UTC)
+	r.RecordDrift(context.Background(), espn.MensCollegeBasketball, espn.KindSummary, "401822894", seen, d)
+	r.RecordDrift(context.Background(), espn.MensCollegeBasketball, espn.KindSummary, "401822894", seen.Add(time.Minute), d)
+
+	if len(store.findings) != 6 {
+		t.Fatalf("expected every finding recorded on every fetch, got %d", len(store.findings))
+	}
+	byKind := make(map[string]models.SchemaDrift)
+	for _, f := range store.findings[:3] {
+		byKind[f.Kind] = f
+	}
+	if f := byKind[models.DriftMissingField]; f.Name != "plays[].coordinate" || f.Objects != 40 || f.Fetches != 1 {
+		t.Errorf("unexpected missing field finding %+v", f)
+	}
+	if f := byKind[models.DriftPlayType]; f.Name != "Hook Shot" || f.LastKey != "401822894" || f.Payload != "summary" {
+		t.Errorf("unexpected play type finding %+v", f)
+	}
+
+	if n := strings.Count(logs.String(), "schema drift"); n != 3 {
+		t.Errorf("expected one warning per finding, got %d:\n%s", n, logs.String())
+	}
+}
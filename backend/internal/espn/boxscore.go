@@ -0,0 +1,159 @@
+package espn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// labelKeys maps the short column labels to ESPN's stat keys, for payloads
+// that only carry labels.
+var labelKeys = map[string]string{
+	"MIN":  "minutes",
+	"FG":   "fieldGoalsMade-fieldGoalsAttempted",
+	"3PT":  "threePointFieldGoalsMade-threePointFieldGoalsAttempted",
+	"FT":   "freeThrowsMade-freeThrowsAttempted",
+	"OREB": "offensiveRebounds",
+	"DREB": "defensiveRebounds",
+	"REB":  "rebounds",
+	"AST":  "assists",
+	"STL":  "steals",
+	"BLK":  "blocks",
+	"TO":   "turnovers",
+	"PF":   "fouls",
+	"PTS":  "points",
+}
+
+// ParseBoxScore turns the summary's positional box score tables into typed
+// per-player and per-team lines. Team lines come from each table's totals,
+// so they include team rebounds that no player is credited with.
+func ParseBoxScore(gameID string, bs BoxScore) (*models.BoxScore, error) {
+	box := &models.BoxScore{
+		GameID:      gameID,
+		LastUpdated: time.Now(),
+	}
+
+	homeAway := make(map[string]string)
+	for _, t := range bs.Teams {
+		homeAway[t.Team.ID] = t.HomeAway
+	}
+
+	for _, team := range bs.Players {
+		for _, group := range team.Statistics {
+			keys := group.Keys
+			if len(keys) == 0 {
+				for _, label := range group.Labels {
+					keys = append(keys, labelKeys[label])
+				}
+			}
+			if len(keys) == 0 {
+				return nil, fmt.Errorf("espn: box score for team %s has no column keys", team.Team.ID)
+			}
+
+			for _, a := range group.Athletes {
+				box.Players = append(box.Players, models.PlayerBoxScore{
+					PlayerID:     a.Athlete.ID,
+					PlayerName:   a.Athlete.DisplayName,
+					Jersey:       a.Athlete.Jersey,
+					Position:     a.Athlete.Position.Abbreviation,
+					TeamID:       team.Team.ID,
+					Starter:      a.Starter,
+					DidNotPlay:   a.DidNotPlay,
+					BoxScoreLine: parseLine(keys, a.Stats),
+				})
+			}
+
+			if len(group.Totals) > 0 {
+				box.Teams = append(box.Teams, models.TeamBoxScore{
+					TeamID:       team.Team.ID,
+					TeamName:     team.Team.DisplayName,
+					HomeAway:     homeAway[team.Team.ID],
+					BoxScoreLine: parseLine(keys, group.Totals),
+				})
+			}
+		}
+	}
+
+	return box, nil
+}
+
+func parseLine(keys, values []string) models.BoxScoreLine {
+	var line models.BoxScoreLine
+
+	for i, key := range keys {
+		if i >= len(values) {
+			break
+		}
+		v := values[i]
+
+		switch key {
+		case "minutes":
+			line.Minutes = atoi(v)
+		case "fieldGoalsMade-fieldGoalsAttempted":
+			line.FGM, line.FGA = madeAttempted(v)
+		case "threePointFieldGoalsMade-threePointFieldGoalsAttempted":
+			line.ThreePM, line.ThreePA = madeAttempted(v)
+		case "freeThrowsMade-freeThrowsAttempted":
+			line.FTM, line.FTA = madeAttempted(v)
+		case "offensiveRebounds":
+			line.OffRebounds = atoi(v)
+		case "defensiveRebounds":
+			line.DefRebounds = atoi(v)
+		case "rebounds":
+			line.Rebounds = atoi(v)
+		case "assists":
+			line.Assists = atoi(v)
+		case "steals":
+			line.Steals = atoi(v)
+		case "blocks":
+			line.Blocks = atoi(v)
+		case "turnovers":
+			line.Turnovers = atoi(v)
+		case "fouls":
+			line.Fouls = atoi(v)
+		case "points":
+			line.Points = atoi(v)
+		}
+	}
+
+	return line
+}
+
+// atoi treats ESPN's placeholders ("", "--") as zero.
+func atoi(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(s))
+	return n
+}
+
+func madeAttempted(s string) (int, int) {
+	made, attempted, _ := strings.Cut(s, "-")
+	return atoi(made), atoi(attempted)
+}
+
+// ParseRoster lists every athlete in the box score, including those who did
+// not play, so names resolve for anyone who can appear in the play-by-play.
+func ParseRoster(bs BoxScore) []models.Player {
+	var players []models.Player
+	now := time.Now()
+
+	for _, team := range bs.Players {
+		for _, group := range team.Statistics {
+			for _, a := range group.Athletes {
+				players = append(players, models.Player{
+					ID:          a.Athlete.ID,
+					DisplayName: a.Athlete.DisplayName,
+					ShortName:   a.Athlete.ShortName,
+					Jersey:      a.Athlete.Jersey,
+					Position:    a.Athlete.Position.Abbreviation,
+					TeamID:      team.Team.ID,
+					LastUpdated: now,
+				})
+			}
+		}
+	}
+
+	return players
+}
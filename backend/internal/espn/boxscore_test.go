@@ -0,0 +1,83 @@
+package espn_test
+
+import (
+	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+)
+
+func TestParseBoxScore(t *testing.T) {
+	summary, err := espntest.LoadSummary(espntest.League, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+
+	box, err := espn.ParseBoxScore(espntest.FinalGameID, summary.BoxScore)
+	if err != nil {
+		t.Fatalf("ParseBoxScore: %v", err)
+	}
+
+	if len(box.Players) != 18 {
+		t.Errorf("expected 18 players, got %d", len(box.Players))
+	}
+
+	points := make(map[string]int)
+	for _, team := range box.Teams {
+		points[team.HomeAway] = team.Points
+	}
+	if points["home"] != 67 || points["away"] != 66 {
+		t.Errorf("expected team totals 67-66, got %d-%d", points["home"], points["away"])
+	}
+
+	for _, player := range box.Players {
+		if player.PlayerID != "4433500" {
+			continue
+		}
+		if player.PlayerName != "Malik Sandoval" || player.Jersey != "1" || player.Position != "G" || !player.Starter {
+			t.Errorf("unexpected player info: %+v", player)
+		}
+		line := player.BoxScoreLine
+		if line.Minutes != 34 || line.FGM != 6 || line.FGA != 11 || line.ThreePM != 1 || line.ThreePA != 3 ||
+			line.OffRebounds != 2 || line.DefRebounds != 5 || line.Rebounds != 7 || line.Assists != 2 ||
+			line.Steals != 3 || line.Turnovers != 1 || line.Fouls != 1 || line.Points != 13 {
+			t.Errorf("unexpected line: %+v", line)
+		}
+	}
+}
+
+func TestParseBoxScorePreGame(t *testing.T) {
+	summary, err := espntest.LoadSummary(espntest.League, espntest.PreGameID)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+
+	box, err := espn.ParseBoxScore(espntest.PreGameID, summary.BoxScore)
+	if err != nil {
+		t.Fatalf("ParseBoxScore: %v", err)
+	}
+	if len(box.Players) != 0 || len(box.Teams) != 0 {
+		t.Errorf("expected an empty box score before tip, got %+v", box)
+	}
+}
+
+func TestParseRoster(t *testing.T) {
+	summary, err := espntest.LoadSummary(espntest.League, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+
+	roster := espn.ParseRoster(summary.BoxScore)
+	if len(roster) != 18 {
+		t.Fatalf("expected 18 players, got %d", len(roster))
+	}
+
+	for _, p := range roster {
+		if p.ID == "" || p.DisplayName == "" || p.TeamID == "" {
+			t.Errorf("incomplete roster entry: %+v", p)
+		}
+		if p.ID == "4433500" && (p.DisplayName != "Malik Sandoval" || p.ShortName != "M. Sandoval" || p.TeamID != "2509") {
+			t.Errorf("unexpected roster entry: %+v", p)
+		}
+	}
+}
@@ -1,16 +1,41 @@
 package espn
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
+	Retry      RetryPolicy
+	Limiter    *RateLimiter
+	Breaker    *CircuitBreaker
+
+	// Recorder, when set, receives the raw body of every successful fetch
+	// before it is decoded.
+	Recorder Recorder
+
+	// Drift, when set, receives any schema drift found in a successfully
+	// decoded payload.
+	Drift DriftRecorder
+}
+
+// Payload kinds passed to a Recorder.
+const (
+	KindScoreboard = "scoreboard"
+	KindSummary    = "summary"
+)
+
+// Recorder keeps raw ESPN payloads so they can be re-decoded later. key is
+// the scoreboard date or the summary's game ID.
+type Recorder interface {
+	Record(ctx context.Context, league League, kind, key string, fetchedAt time.Time, body []byte)
 }
 
 func NewClient(baseURL string) *Client {
@@ -19,53 +44,194 @@ func NewClient(baseURL string) *Client {
 		HTTPClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		Retry:   DefaultRetryPolicy,
+		Limiter: NewRateLimiter(5, 5),
+		Breaker: NewCircuitBreaker(5, 30*time.Second),
 	}
 }
 
-// GetScoreboard fetches all games for a given date
-func (c *Client) GetScoreboard(date string) (*ScoreboardResponse, error) {
-	url := fmt.Sprintf("%s/apis/site/v2/sports/basketball/mens-college-basketball/scoreboard?dates=%s&limit=500",
-		c.BaseURL, date)
+// GetScoreboard fetches all games in a league for a given date
+func (c *Client) GetScoreboard(ctx context.Context, league League, date string) (*ScoreboardResponse, error) {
+	url := fmt.Sprintf("%s/apis/site/v2/sports/basketball/%s/scoreboard?dates=%s&limit=500",
+		c.BaseURL, league, date)
 
-	resp, err := c.HTTPClient.Get(url)
+	body, err := c.fetchPayload(ctx, league, KindScoreboard, date, url)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	scoreboard, err := DecodeScoreboard(body)
+	if err != nil {
+		return nil, &DecodeError{URL: url, Err: err}
+	}
+	c.checkDrift(ctx, league, KindScoreboard, date, body)
+
+	return scoreboard, nil
+}
+
+// GetGameSummary fetches complete game data including plays, box score, etc.
+func (c *Client) GetGameSummary(ctx context.Context, league League, gameID string) (*GameSummary, error) {
+	url := fmt.Sprintf("%s/apis/site/v2/sports/basketball/%s/summary?event=%s",
+		c.BaseURL, league, gameID)
+
+	body, err := c.fetchPayload(ctx, league, KindSummary, gameID, url)
 	if err != nil {
 		return nil, err
 	}
 
+	summary, err := DecodeSummary(body)
+	if err != nil {
+		return nil, &DecodeError{URL: url, Err: err}
+	}
+	c.checkDrift(ctx, league, KindSummary, gameID, body)
+
+	return summary, nil
+}
+
+// Ping checks that ESPN answers by fetching one men's scoreboard entry. It
+// makes a single attempt outside the retry policy, rate limiter and circuit
+// breaker, so health probes neither wait on nor disturb real traffic.
+func (c *Client) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("%s/apis/site/v2/sports/basketball/%s/scoreboard?limit=1", c.BaseURL, MensCollegeBasketball)
+	_, err := c.fetch(ctx, url)
+	return err
+}
+
+// DecodeScoreboard decodes a raw scoreboard payload, live or archived.
+func DecodeScoreboard(body []byte) (*ScoreboardResponse, error) {
 	var scoreboard ScoreboardResponse
 	if err := json.Unmarshal(body, &scoreboard); err != nil {
 		return nil, err
 	}
-
 	return &scoreboard, nil
 }
 
-// GetGameSummary fetches complete game data including plays, box score, etc.
-func (c *Client) GetGameSummary(gameID string) (*GameSummary, error) {
-	url := fmt.Sprintf("%s/apis/site/v2/sports/basketball/mens-college-basketball/summary?event=%s",
-		c.BaseURL, gameID)
+// DecodeSummary decodes a raw game summary payload, live or archived.
+func DecodeSummary(body []byte) (*GameSummary, error) {
+	var summary GameSummary
+	if err := json.Unmarshal(body, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
 
-	resp, err := c.HTTPClient.Get(url)
+func (c *Client) fetchPayload(ctx context.Context, league League, kind, key, url string) ([]byte, error) {
+	body, err := c.get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	if c.Recorder != nil {
+		c.Recorder.Record(ctx, league, kind, key, time.Now(), body)
+	}
+	return body, nil
+}
+
+func (c *Client) checkDrift(ctx context.Context, league League, kind, key string, body []byte) {
+	if c.Drift == nil {
+		return
+	}
+
+	var drift Drift
+	if kind == KindScoreboard {
+		drift = CheckScoreboard(body)
+	} else {
+		drift = CheckSummary(body)
+	}
+	if !drift.Empty() {
+		c.Drift.RecordDrift(ctx, league, kind, key, time.Now(), drift)
+	}
+}
+
+// get performs a GET with rate limiting, bounded retries and the circuit
+// breaker, returning the body of the first 2xx response. Cancelling ctx
+// aborts the in-flight request as well as any backoff or limiter wait.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	attempts := max(c.Retry.MaxAttempts, 1)
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			var retryAfter time.Duration
+			if httpErr, ok := err.(*HTTPError); ok {
+				retryAfter = httpErr.RetryAfter
+			}
+			if err := sleep(ctx, c.Retry.backoff(attempt-1, retryAfter)); err != nil {
+				return nil, err
+			}
+		}
+
+		if err = c.Limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		if err = c.Breaker.Allow(); err != nil {
+			return nil, err
+		}
+
+		var body []byte
+		body, err = c.fetch(ctx, url)
+		if ctx.Err() != nil {
+			// Our own cancellation says nothing about ESPN's health.
+			c.Breaker.release()
+			return nil, ctx.Err()
+		}
+		c.Breaker.Record(err)
+		if err == nil {
+			return body, nil
+		}
+		if !retryable(err) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var summary GameSummary
-	if err := json.Unmarshal(body, &summary); err != nil {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	return &summary, nil
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		io.Copy(io.Discard, resp.Body)
+		return nil, &HTTPError{
+			StatusCode: resp.StatusCode,
+			URL:        url,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
 }
@@ -1,34 +1,110 @@
-package espn
+package espn_test
 
 import (
-	"fmt"
+	"context"
 	"testing"
-	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
 )
 
 func TestGetScoreboard(t *testing.T) {
-	client := NewClient("https://site.api.espn.com")
-
-	// Get today's games
-	today := time.Now().Format("20060102")
+	srv := espntest.NewServer()
+	defer srv.Close()
 
-	fmt.Printf("Fetching games for date: %s\n", today)
+	client := espn.NewClient(srv.URL)
 
-	scoreboard, err := client.GetScoreboard(today)
+	scoreboard, err := client.GetScoreboard(context.Background(), espn.MensCollegeBasketball, espntest.GameDate)
 	if err != nil {
 		t.Fatalf("Error fetching scoreboard: %v", err)
 	}
 
-	fmt.Printf("\n✅ SUCCESS! Found %d games\n\n", len(scoreboard.Events))
+	if len(scoreboard.Events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(scoreboard.Events))
+	}
+
+	states := make(map[string]string)
+	for _, event := range scoreboard.Events {
+		states[event.ID] = event.Status.Type.State
+
+		if len(event.Competitions) != 1 {
+			t.Fatalf("event %s: expected 1 competition, got %d", event.ID, len(event.Competitions))
+		}
+		if len(event.Competitions[0].Competitors) != 2 {
+			t.Errorf("event %s: expected 2 competitors, got %d", event.ID, len(event.Competitions[0].Competitors))
+		}
+	}
 
-	// Print first 5 games
-	for i, event := range scoreboard.Events {
-		if i >= 5 {
-			break
+	want := map[string]string{
+		espntest.FinalGameID: "post",
+		espntest.LiveGameID:  "in",
+		espntest.PreGameID:   "pre",
+	}
+	for id, state := range want {
+		if states[id] != state {
+			t.Errorf("event %s: expected state %q, got %q", id, state, states[id])
 		}
-		fmt.Printf("%d. %s\n", i+1, event.Name)
-		fmt.Printf("   ID: %s\n", event.ID)
-		fmt.Printf("   Status: %s\n", event.Status.Type.State)
-		fmt.Printf("   Time: %s\n\n", event.Date)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 1 || reqs[0] != "/apis/site/v2/sports/basketball/mens-college-basketball/scoreboard?dates=20260315&limit=500" {
+		t.Errorf("unexpected requests: %v", reqs)
+	}
+}
+
+func TestGetScoreboardNoGames(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+
+	client := espn.NewClient(srv.URL)
+
+	scoreboard, err := client.GetScoreboard(context.Background(), espn.MensCollegeBasketball, "20260704")
+	if err != nil {
+		t.Fatalf("Error fetching scoreboard: %v", err)
+	}
+	if len(scoreboard.Events) != 0 {
+		t.Errorf("expected no events, got %d", len(scoreboard.Events))
+	}
+}
+
+func TestGetScoreboardWomens(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+
+	client := espn.NewClient(srv.URL)
+
+	scoreboard, err := client.GetScoreboard(context.Background(), espn.WomensCollegeBasketball, espntest.GameDate)
+	if err != nil {
+		t.Fatalf("Error fetching scoreboard: %v", err)
+	}
+	if len(scoreboard.Events) != 1 || scoreboard.Events[0].ID != espntest.WomensGameID {
+		t.Fatalf("expected only women's game %s, got %+v", espntest.WomensGameID, scoreboard.Events)
+	}
+
+	summary, err := client.GetGameSummary(context.Background(), espn.WomensCollegeBasketball, espntest.WomensGameID)
+	if err != nil {
+		t.Fatalf("Error fetching game summary: %v", err)
+	}
+	if summary.Header.ID != espntest.WomensGameID {
+		t.Errorf("expected header id %s, got %s", espntest.WomensGameID, summary.Header.ID)
+	}
+
+	// A men's lookup of the same event must not leak across leagues.
+	if _, err := client.GetGameSummary(context.Background(), espn.MensCollegeBasketball, espntest.WomensGameID); err == nil {
+		t.Error("expected women's event to be unknown in the men's league")
+	}
+}
+
+func TestParseLeagues(t *testing.T) {
+	leagues, err := espn.ParseLeagues("mens-college-basketball, WNBA")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(leagues) != 2 || leagues[0] != espn.MensCollegeBasketball || leagues[1] != espn.WNBA {
+		t.Errorf("unexpected leagues: %v", leagues)
+	}
+
+	if _, err := espn.ParseLeagues("mens-college-football"); err == nil {
+		t.Error("expected unknown league to be rejected")
 	}
 }
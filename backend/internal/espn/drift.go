@@ -0,0 +1,222 @@
+package espn
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Drift is how a payload differs from what decoding expects. ESPN renames
+// and drops fields without notice, and encoding/json turns those into zero
+// values without complaint, so every decoded payload is also checked here.
+type Drift struct {
+	// Unknown lists play-by-play fields our types do not decode, by path,
+	// e.g. "plays[].coordinates".
+	Unknown []string
+	// Missing counts, by path, the objects lacking a field we rely on,
+	// e.g. "plays[].coordinate" for shots without a location.
+	Missing map[string]int
+	// PlayTypes lists play type texts outside KnownPlayTypes.
+	PlayTypes []string
+}
+
+func (d Drift) Empty() bool {
+	return len(d.Unknown) == 0 && len(d.Missing) == 0 && len(d.PlayTypes) == 0
+}
+
+// DriftRecorder receives the drift found in a fetched payload. key is the
+// scoreboard date or the summary's game ID.
+type DriftRecorder interface {
+	RecordDrift(ctx context.Context, league League, kind, key string, seenAt time.Time, drift Drift)
+}
+
+// KnownPlayTypes are the play type texts the analyzer and role inference
+// were written against.
+var KnownPlayTypes = map[string]bool{
+	"JumpShot":           true,
+	"LayUpShot":          true,
+	"DunkShot":           true,
+	"TipShot":            true,
+	"MadeFreeThrow":      true,
+	"MissedFreeThrow":    true,
+	"Offensive Rebound":  true,
+	"Defensive Rebound":  true,
+	"Dead Ball Rebound":  true,
+	"Block Shot":         true,
+	"Steal":              true,
+	"Lost Ball Turnover": true,
+	"Bad Pass Turnover":  true,
+	"Traveling":          true,
+	"Turnover":           true,
+	"PersonalFoul":       true,
+	"Technical Foul":     true,
+	"Flagrant Foul":      true,
+	"Jumpball":           true,
+	"Substitution":       true,
+	"OfficialTVTimeOut":  true,
+	"ShortTimeOut":       true,
+	"RegularTimeOut":     true,
+	"End Period":         true,
+	"End Game":           true,
+}
+
+// CheckScoreboard reports the drift in a raw scoreboard payload.
+func CheckScoreboard(body []byte) Drift {
+	var raw map[string]any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return Drift{}
+	}
+
+	c := newDriftCheck()
+	c.require("", raw, "events")
+	for _, event := range objects(raw["events"]) {
+		c.require("events[]", event, "id", "date", "status.type.state", "competitions")
+		for _, comp := range objects(event["competitions"]) {
+			c.require("events[].competitions[]", comp, "competitors")
+			for _, competitor := range objects(comp["competitors"]) {
+				c.require("events[].competitions[].competitors[]", competitor, "team.id", "homeAway", "score")
+			}
+		}
+	}
+	return c.drift()
+}
+
+// CheckSummary reports the drift in a raw game summary payload. Plays are
+// checked field by field, since that is where a silent zero value skews the
+// analytics.
+func CheckSummary(body []byte) Drift {
+	var raw map[string]any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return Drift{}
+	}
+
+	c := newDriftCheck()
+	c.require("", raw, "header.id", "header.competitions", "boxscore")
+
+	for _, play := range objects(raw["plays"]) {
+		c.known("plays[]", play, reflect.TypeFor[Play]())
+		c.known("plays[].type", child(play, "type"), reflect.TypeFor[PlayType]())
+		c.known("plays[].period", child(play, "period"), reflect.TypeFor[Period]())
+		c.known("plays[].clock", child(play, "clock"), reflect.TypeFor[Clock]())
+		c.known("plays[].coordinate", child(play, "coordinate"), reflect.TypeFor[Coordinate]())
+		for _, p := range objects(play["participants"]) {
+			c.known("plays[].participants[]", p, reflect.TypeFor[Participant]())
+		}
+
+		c.require("plays[]", play, "id", "sequenceNumber", "type.text", "period.number", "clock.displayValue",
+			"awayScore", "homeScore", "scoringPlay", "scoreValue", "shootingPlay", "wallclock")
+
+		typeText, _ := lookup(play, "type.text").(string)
+		if shooting, _ := play["shootingPlay"].(bool); shooting && !strings.Contains(typeText, "FreeThrow") {
+			c.require("plays[]", play, "coordinate")
+		}
+		if len(objects(play["participants"])) > 0 {
+			c.require("plays[]", play, "team.id")
+		}
+		if typeText != "" && !KnownPlayTypes[typeText] {
+			c.playTypes[typeText] = true
+		}
+	}
+	return c.drift()
+}
+
+type driftCheck struct {
+	unknown   map[string]bool
+	missing   map[string]int
+	playTypes map[string]bool
+}
+
+func newDriftCheck() *driftCheck {
+	return &driftCheck{
+		unknown:   make(map[string]bool),
+		missing:   make(map[string]int),
+		playTypes: make(map[string]bool),
+	}
+}
+
+// known flags the keys of obj that t does not decode.
+func (c *driftCheck) known(path string, obj map[string]any, t reflect.Type) {
+	if obj == nil {
+		return
+	}
+	fields := jsonFields(t)
+	for key := range obj {
+		if !fields[key] {
+			c.unknown[path+"."+key] = true
+		}
+	}
+}
+
+// require counts obj as missing each of the dotted field paths it lacks.
+func (c *driftCheck) require(path string, obj map[string]any, fields ...string) {
+	for _, field := range fields {
+		if lookup(obj, field) == nil {
+			c.missing[strings.TrimPrefix(path+"."+field, ".")]++
+		}
+	}
+}
+
+func (c *driftCheck) drift() Drift {
+	d := Drift{
+		Unknown:   sortedKeys(c.unknown),
+		PlayTypes: sortedKeys(c.playTypes),
+	}
+	if len(c.missing) > 0 {
+		d.Missing = c.missing
+	}
+	return d
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonFields returns the JSON names a struct type decodes.
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+func objects(v any) []map[string]any {
+	list, _ := v.([]any)
+	var out []map[string]any
+	for _, item := range list {
+		if obj, ok := item.(map[string]any); ok {
+			out = append(out, obj)
+		}
+	}
+	return out
+}
+
+func child(obj map[string]any, key string) map[string]any {
+	m, _ := obj[key].(map[string]any)
+	return m
+}
+
+// lookup follows a dotted path through nested objects, returning nil if any
+// step is absent or null.
+func lookup(obj map[string]any, path string) any {
+	var v any = obj
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
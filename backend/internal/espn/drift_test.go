@@ -0,0 +1,99 @@
+package espn_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+)
+
+func TestCheckFixturesHaveNoDrift(t *testing.T) {
+	for _, id := range []string{espntest.FinalGameID, espntest.LiveGameID, espntest.PreGameID} {
+		body, err := espntest.Fixture(espntest.League, espn.KindSummary, id)
+		if err != nil {
+			t.Fatalf("loading fixture: %v", err)
+		}
+		if d := espn.CheckSummary(body); !d.Empty() {
+			t.Errorf("summary %s: unexpected drift %+v", id, d)
+		}
+	}
+
+	body, err := espntest.Fixture(espntest.League, espn.KindScoreboard, espntest.GameDate)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+	if d := espn.CheckScoreboard(body); !d.Empty() {
+		t.Errorf("scoreboard: unexpected drift %+v", d)
+	}
+}
+
+func TestCheckSummaryDrift(t *testing.T) {
+	body, err := espntest.Fixture(espntest.League, espn.KindSummary, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+
+	// Rename every coordinate and invent a play type.
+	var raw map[string]any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatal(err)
+	}
+	shots := 0
+	for _, p := range raw["plays"].([]any) {
+		play := p.(map[string]any)
+		if c, ok := play["coordinate"]; ok {
+			play["coordinates"] = c
+			delete(play, "coordinate")
+			shots++
+		}
+	}
+	raw["plays"].([]any)[0].(map[string]any)["type"].(map[string]any)["text"] = "Hook Shot"
+	body, _ = json.Marshal(raw)
+
+	d := espn.CheckSummary(body)
+	if !slices.Equal(d.Unknown, []string{"plays[].coordinates"}) {
+		t.Errorf("expected the renamed field to be unknown, got %v", d.Unknown)
+	}
+	if d.Missing["plays[].coordinate"] != shots {
+		t.Errorf("expected %d shots missing a coordinate, got %v", shots, d.Missing)
+	}
+	if !slices.Equal(d.PlayTypes, []string{"Hook Shot"}) {
+		t.Errorf("expected the new play type, got %v", d.PlayTypes)
+	}
+}
+
+type driftLog struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (l *driftLog) RecordDrift(ctx context.Context, league espn.League, kind, key string, seenAt time.Time, drift espn.Drift) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.keys = append(l.keys, kind+"/"+key)
+}
+
+func TestClientRecordsOnlyDrift(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+
+	log := &driftLog{}
+	client := espn.NewClient(srv.URL)
+	client.Drift = log
+
+	ctx := context.Background()
+	if _, err := client.GetScoreboard(ctx, espn.MensCollegeBasketball, espntest.GameDate); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetGameSummary(ctx, espn.MensCollegeBasketball, espntest.FinalGameID); err != nil {
+		t.Fatal(err)
+	}
+	if len(log.keys) != 0 {
+		t.Errorf("expected no drift from the fixtures, got %v", log.keys)
+	}
+}
@@ -0,0 +1,67 @@
+package espn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrNotFound    = errors.New("espn: not found")
+	ErrThrottled   = errors.New("espn: throttled")
+	ErrUpstream    = errors.New("espn: upstream error")
+	ErrDecode      = errors.New("espn: decode error")
+	ErrCircuitOpen = errors.New("espn: circuit open")
+)
+
+// HTTPError is returned for any non-2xx response. It unwraps to ErrNotFound,
+// ErrThrottled or ErrUpstream depending on the status code.
+type HTTPError struct {
+	StatusCode int
+	URL        string
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("espn: %s returned %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *HTTPError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrThrottled
+	default:
+		return ErrUpstream
+	}
+}
+
+// DecodeError is returned when a 2xx body is not the JSON we expect, which is
+// usually an HTML error page served with a success status.
+type DecodeError struct {
+	URL string
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("espn: decoding %s: %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Unwrap() []error {
+	return []error{ErrDecode, e.Err}
+}
+
+// retryable reports whether another attempt could plausibly succeed.
+func retryable(err error) bool {
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrDecode) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	// Transport errors (timeouts, resets, DNS) are worth another try.
+	return true
+}
@@ -0,0 +1,151 @@
+package espn_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+)
+
+func newTestClient(url string) *espn.Client {
+	client := espn.NewClient(url)
+	client.Retry = espn.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	client.Limiter = nil
+	return client
+}
+
+func TestRetriesUpstreamErrors(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+	srv.FailNext(2, http.StatusServiceUnavailable, "")
+
+	client := newTestClient(srv.URL)
+
+	summary, err := client.GetGameSummary(context.Background(), espn.MensCollegeBasketball, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("expected retries to recover, got %v", err)
+	}
+	if summary.Header.ID != espntest.FinalGameID {
+		t.Errorf("expected header id %s, got %s", espntest.FinalGameID, summary.Header.ID)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestThrottledAfterRetries(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+	srv.FailNext(3, http.StatusTooManyRequests, "1")
+
+	client := newTestClient(srv.URL)
+
+	_, err := client.GetScoreboard(context.Background(), espn.MensCollegeBasketball, espntest.GameDate)
+	if !errors.Is(err, espn.ErrThrottled) {
+		t.Fatalf("expected ErrThrottled, got %v", err)
+	}
+
+	var httpErr *espn.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.RetryAfter != time.Second {
+		t.Errorf("expected Retry-After of 1s to be surfaced, got %v", err)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestNotFoundIsNotRetried(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+
+	_, err := client.GetGameSummary(context.Background(), espn.MensCollegeBasketball, "401000000")
+	if !errors.Is(err, espn.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestHTMLBodyIsDecodeError(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+	srv.FailNext(1, http.StatusOK, "")
+
+	client := newTestClient(srv.URL)
+
+	_, err := client.GetScoreboard(context.Background(), espn.MensCollegeBasketball, espntest.GameDate)
+	if !errors.Is(err, espn.ErrDecode) {
+		t.Fatalf("expected ErrDecode, got %v", err)
+	}
+}
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+	srv.FailNext(6, http.StatusBadGateway, "")
+
+	client := newTestClient(srv.URL)
+	client.Breaker = espn.NewCircuitBreaker(3, time.Hour)
+
+	_, err := client.GetScoreboard(context.Background(), espn.MensCollegeBasketball, espntest.GameDate)
+	if !errors.Is(err, espn.ErrUpstream) {
+		t.Fatalf("expected ErrUpstream, got %v", err)
+	}
+
+	_, err = client.GetScoreboard(context.Background(), espn.MensCollegeBasketball, espntest.GameDate)
+	if !errors.Is(err, espn.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("expected breaker to stop calls after 3 requests, got %d", n)
+	}
+}
+
+func TestCancelledContextAbortsBackoff(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+	srv.FailNext(3, http.StatusServiceUnavailable, "")
+
+	client := newTestClient(srv.URL)
+	client.Retry = espn.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetScoreboard(ctx, espn.MensCollegeBasketball, espntest.GameDate)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("expected 1 request before the deadline, got %d", n)
+	}
+}
+
+func TestPingSingleAttempt(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("expected ping to succeed, got %v", err)
+	}
+
+	srv.FailNext(1, http.StatusServiceUnavailable, "")
+	err := client.Ping(context.Background())
+	if !errors.Is(err, espn.ErrUpstream) {
+		t.Errorf("expected ErrUpstream, got %v", err)
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("expected one request per ping, got %d requests", n)
+	}
+	if client.Breaker.Open() {
+		t.Error("expected pings not to count towards the breaker")
+	}
+}
@@ -0,0 +1,183 @@
+// Package espntest provides an offline stand-in for the ESPN site API.
+//
+// The server answers the same scoreboard and summary routes that espn.Client
+// builds, serving payloads from the fixture corpus under testdata/<league>/.
+// The corpus covers one final (401822893), one live (401822894) and one
+// scheduled (401822895) game on 2026-03-15; other days serve an empty board.
+// The women's corpus has one scheduled game (401830210) on 2026-03-15.
+//
+// The payloads are assembled in ESPN's shape rather than captured, so they
+// are no substitute for the real feed's quirks. They should be replaced with
+// payloads the poller archived under ARCHIVE_DIR, gunzipped and trimmed only
+// by removing whole games.
+package espntest
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+)
+
+const (
+	League = "mens-college-basketball"
+
+	GameDate    = "20260315"
+	FinalGameID = "401822893"
+	LiveGameID  = "401822894"
+	PreGameID   = "401822895"
+
+	WomensLeague = "womens-college-basketball"
+	WomensGameID = "401830210"
+)
+
+//go:embed testdata
+var fixtures embed.FS
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []string
+	failures []failure
+}
+
+type failure struct {
+	status     int
+	retryAfter string
+}
+
+// NewServer starts a fake ESPN server. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Requests returns the request URIs received so far, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// FailNext makes the next n requests answer with status and an HTML error
+// page, the way ESPN's edge does when it is throttling or falling over. A
+// status of 200 serves the HTML page with a success code.
+func (s *Server) FailNext(n, status int, retryAfter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{status: status, retryAfter: retryAfter})
+	}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL.RequestURI())
+	var fail *failure
+	if len(s.failures) > 0 {
+		fail = &s.failures[0]
+		s.failures = s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	if fail != nil {
+		if fail.retryAfter != "" {
+			w.Header().Set("Retry-After", fail.retryAfter)
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(fail.status)
+		w.Write([]byte("<html><body><h1>Service Unavailable</h1></body></html>"))
+		return
+	}
+
+	league, route, ok := parsePath(r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown route")
+		return
+	}
+
+	switch route {
+	case "scoreboard":
+		body, err := Fixture(league, "scoreboard", r.URL.Query().Get("dates"))
+		if errors.Is(err, fs.ErrNotExist) {
+			// ESPN answers days without games with an empty board, not a 404.
+			body = []byte(`{"events":[]}`)
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, body)
+
+	case "summary":
+		body, err := Fixture(league, "summary", r.URL.Query().Get("event"))
+		if err != nil {
+			writeError(w, http.StatusNotFound, "event not found")
+			return
+		}
+		writeJSON(w, body)
+
+	default:
+		writeError(w, http.StatusNotFound, "unknown route")
+	}
+}
+
+// parsePath splits /apis/site/v2/sports/basketball/{league}/{route}.
+func parsePath(p string) (league, route string, ok bool) {
+	rest, found := strings.CutPrefix(p, "/apis/site/v2/sports/basketball/")
+	if !found {
+		return "", "", false
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func writeJSON(w http.ResponseWriter, body []byte) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"code": status, "message": msg})
+}
+
+// Fixture returns the raw recorded payload for a scoreboard date or summary event.
+func Fixture(league, kind, key string) ([]byte, error) {
+	if key == "" || strings.ContainsAny(key, "/.") {
+		return nil, fs.ErrNotExist
+	}
+	return fixtures.ReadFile(path.Join("testdata", league, kind, key+".json"))
+}
+
+// LoadScoreboard decodes a recorded scoreboard without going through HTTP.
+func LoadScoreboard(league, date string) (*espn.ScoreboardResponse, error) {
+	body, err := Fixture(league, "scoreboard", date)
+	if err != nil {
+		return nil, err
+	}
+
+	return espn.DecodeScoreboard(body)
+}
+
+// LoadSummary decodes a recorded game summary without going through HTTP.
+func LoadSummary(league, gameID string) (*espn.GameSummary, error) {
+	body, err := Fixture(league, "summary", gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return espn.DecodeSummary(body)
+}
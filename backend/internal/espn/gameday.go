@@ -0,0 +1,30 @@
+package espn
+
+import (
+	"time"
+	_ "time/tzdata" // the zone must resolve on hosts without zoneinfo
+)
+
+// Eastern is the time zone ESPN's scoreboard dates and US game days are in.
+var Eastern = mustLoadLocation("America/New_York")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// GameDay returns the Eastern calendar day t falls on, as midnight UTC, so
+// that stored game days compare as plain dates whatever the server's zone.
+func GameDay(t time.Time) time.Time {
+	y, m, d := t.In(Eastern).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// ScoreboardDate formats the Eastern day t falls on as a scoreboard
+// dates= parameter, YYYYMMDD.
+func ScoreboardDate(t time.Time) string {
+	return t.In(Eastern).Format("20060102")
+}
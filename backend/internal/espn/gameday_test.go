@@ -0,0 +1,48 @@
+package espn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+)
+
+func TestGameDay(t *testing.T) {
+	tests := []struct {
+		at   string
+		day  string
+		date string
+	}{
+		// 7:30 PM EDT.
+		{"2026-03-15T23:30:00Z", "2026-03-15", "20260315"},
+		// 10:30 PM PDT tip is already the next day in UTC but not in ET.
+		{"2026-03-16T03:30:00Z", "2026-03-15", "20260315"},
+		// Just after midnight ET.
+		{"2026-03-16T04:05:00Z", "2026-03-16", "20260316"},
+		// Standard time, 9 PM EST.
+		{"2026-01-11T02:00:00Z", "2026-01-10", "20260110"},
+	}
+
+	for _, tt := range tests {
+		at, err := time.Parse(time.RFC3339, tt.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		day := espn.GameDay(at)
+		if got := day.Format(time.DateOnly); got != tt.day {
+			t.Errorf("%s: expected game day %s, got %s", tt.at, tt.day, got)
+		}
+		if day.Location() != time.UTC || day.Hour() != 0 {
+			t.Errorf("%s: expected midnight UTC, got %v", tt.at, day)
+		}
+		if got := espn.ScoreboardDate(at); got != tt.date {
+			t.Errorf("%s: expected scoreboard date %s, got %s", tt.at, tt.date, got)
+		}
+
+		// The server's own zone must not matter.
+		if got := espn.GameDay(at.In(time.FixedZone("JST", 9*3600))); !got.Equal(day) {
+			t.Errorf("%s: game day depends on the input's zone: %v vs %v", tt.at, got, day)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package espn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// League is the ESPN sport path segment under /sports/basketball/.
+type League string
+
+const (
+	MensCollegeBasketball   League = "mens-college-basketball"
+	WomensCollegeBasketball League = "womens-college-basketball"
+	NBA                     League = "nba"
+	WNBA                    League = "wnba"
+)
+
+var Leagues = []League{MensCollegeBasketball, WomensCollegeBasketball, NBA, WNBA}
+
+// RegulationPeriods is the number of periods in a regulation game: two
+// halves in men's college basketball, four quarters everywhere else.
+func (l League) RegulationPeriods() int {
+	if l == MensCollegeBasketball {
+		return 2
+	}
+	return 4
+}
+
+func ParseLeague(s string) (League, error) {
+	for _, l := range Leagues {
+		if strings.EqualFold(s, string(l)) {
+			return l, nil
+		}
+	}
+	return "", fmt.Errorf("espn: unknown league %q", s)
+}
+
+// ParseLeagues parses a comma-separated league list such as
+// "mens-college-basketball,womens-college-basketball".
+func ParseLeagues(s string) ([]League, error) {
+	var leagues []League
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		l, err := ParseLeague(part)
+		if err != nil {
+			return nil, err
+		}
+		leagues = append(leagues, l)
+	}
+	return leagues, nil
+}
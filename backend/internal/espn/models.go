@@ -14,6 +14,7 @@ type Event struct {
 
 type Status struct {
 	Type struct {
+		Name        string `json:"name"`
 		State       string `json:"state"`
 		Description string `json:"description"`
 	} `json:"type"`
@@ -22,15 +23,25 @@ type Status struct {
 }
 
 type Competition struct {
-	ID          string       `json:"id"`
-	Competitors []Competitor `json:"competitors"`
+	ID                    string       `json:"id"`
+	Date                  string       `json:"date"`
+	Attendance            int          `json:"attendance"`
+	NeutralSite           bool         `json:"neutralSite"`
+	ConferenceCompetition bool         `json:"conferenceCompetition"`
+	Venue                 Venue        `json:"venue"`
+	Competitors           []Competitor `json:"competitors"`
+	Broadcasts            []Broadcast  `json:"broadcasts"`
+	Groups                *Group       `json:"groups,omitempty"`
 }
 
 type Competitor struct {
-	ID       string `json:"id"`
-	Team     Team   `json:"team"`
-	HomeAway string `json:"homeAway"`
-	Score    string `json:"score"`
+	ID          string   `json:"id"`
+	Team        Team     `json:"team"`
+	HomeAway    string   `json:"homeAway"`
+	Score       string   `json:"score"`
+	Winner      bool     `json:"winner"`
+	CuratedRank *Rank    `json:"curatedRank,omitempty"`
+	Records     []Record `json:"records"`
 }
 
 type Team struct {
@@ -39,6 +50,39 @@ type Team struct {
 	DisplayName  string `json:"displayName"`
 	Abbreviation string `json:"abbreviation"`
 	Logo         string `json:"logo"`
+	ConferenceID string `json:"conferenceId"`
+}
+
+// Rank is the AP poll position; ESPN uses 99 for unranked teams.
+type Rank struct {
+	Current int `json:"current"`
+}
+
+type Record struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Summary string `json:"summary"`
+}
+
+type Venue struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullName"`
+	Address  struct {
+		City  string `json:"city"`
+		State string `json:"state"`
+	} `json:"address"`
+}
+
+type Broadcast struct {
+	Market string   `json:"market"`
+	Names  []string `json:"names"`
+}
+
+type Group struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ShortName    string `json:"shortName"`
+	IsConference bool   `json:"isConference"`
 }
 
 // GameSummary with Plays
@@ -49,7 +93,22 @@ type GameSummary struct {
 }
 
 type Header struct {
-	ID string `json:"id"`
+	ID           string              `json:"id"`
+	Competitions []HeaderCompetition `json:"competitions"`
+	League       struct {
+		Slug string `json:"slug"`
+	} `json:"league"`
+}
+
+// HeaderCompetition is the summary's copy of the scoreboard competition. Its
+// broadcasts use a different shape, so only the shared fields are decoded.
+type HeaderCompetition struct {
+	ID                    string       `json:"id"`
+	Date                  string       `json:"date"`
+	NeutralSite           bool         `json:"neutralSite"`
+	ConferenceCompetition bool         `json:"conferenceCompetition"`
+	Competitors           []Competitor `json:"competitors"`
+	Status                Status       `json:"status"`
 }
 
 type BoxScore struct {
@@ -60,26 +119,46 @@ type BoxScore struct {
 type TeamStats struct {
 	Team       Team        `json:"team"`
 	Statistics []Statistic `json:"statistics"`
+	HomeAway   string      `json:"homeAway"`
 }
 
 type PlayerStats struct {
-	Team       Team `json:"team"`
-	Statistics []struct {
-		Athletes []Athlete `json:"athletes"`
-	} `json:"statistics"`
+	Team       Team              `json:"team"`
+	Statistics []PlayerStatGroup `json:"statistics"`
+}
+
+// PlayerStatGroup is one box score table: Keys/Labels name the columns and
+// each athlete's Stats holds the values positionally.
+type PlayerStatGroup struct {
+	Names    []string  `json:"names"`
+	Keys     []string  `json:"keys"`
+	Labels   []string  `json:"labels"`
+	Athletes []Athlete `json:"athletes"`
+	Totals   []string  `json:"totals"`
 }
 
 type Athlete struct {
-	Athlete struct {
-		ID          string `json:"id"`
-		DisplayName string `json:"displayName"`
-	} `json:"athlete"`
-	Stats []string `json:"stats"`
+	Athlete    AthleteInfo `json:"athlete"`
+	Starter    bool        `json:"starter"`
+	DidNotPlay bool        `json:"didNotPlay"`
+	Ejected    bool        `json:"ejected"`
+	Stats      []string    `json:"stats"`
+}
+
+type AthleteInfo struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	ShortName   string `json:"shortName"`
+	Jersey      string `json:"jersey"`
+	Position    struct {
+		Abbreviation string `json:"abbreviation"`
+	} `json:"position"`
 }
 
 type Statistic struct {
 	Name         string `json:"name"`
 	DisplayValue string `json:"displayValue"`
+	Label        string `json:"label"`
 }
 
 // Play-by-play structures
@@ -124,4 +203,5 @@ type Participant struct {
 	Athlete struct {
 		ID string `json:"id"`
 	} `json:"athlete"`
+	Type string `json:"type,omitempty"`
 }
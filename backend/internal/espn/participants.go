@@ -0,0 +1,115 @@
+package espn
+
+import (
+	"strings"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// participantTypes maps the participant "type" values ESPN sends for some
+// leagues onto our roles. When it is absent, roles are inferred from ESPN
+// listing a play's athletes positionally, with the primary actor first.
+var participantTypes = map[string]string{
+	"scorer":   models.RoleShooter,
+	"shooter":  models.RoleShooter,
+	"assister": models.RoleAssister,
+	"blocker":  models.RoleBlocker,
+	"stealer":  models.RoleStealer,
+	"fouler":   models.RoleFouler,
+	"fouled":   models.RoleFouled,
+}
+
+type ParticipantRole struct {
+	AthleteID string
+	Role      string
+}
+
+// ParticipantRoles tags each participant of the play with its role.
+func (p Play) ParticipantRoles() []ParticipantRole {
+	roles := make([]ParticipantRole, 0, len(p.Participants))
+	for i, participant := range p.Participants {
+		role, ok := participantTypes[strings.ToLower(participant.Type)]
+		if !ok {
+			role = p.inferRole(i)
+		}
+		roles = append(roles, ParticipantRole{AthleteID: participant.Athlete.ID, Role: role})
+	}
+	return roles
+}
+
+// Athlete returns the first athlete with the given role, or "".
+func (p Play) Athlete(role string) string {
+	for _, r := range p.ParticipantRoles() {
+		if r.Role == role {
+			return r.AthleteID
+		}
+	}
+	return ""
+}
+
+func (p Play) inferRole(index int) string {
+	playType := strings.ToLower(p.Type.Text)
+	text := strings.ToLower(p.Text)
+
+	switch {
+	case p.ShootingPlay || isShotType(playType):
+		if index == 0 {
+			return models.RoleShooter
+		}
+		if strings.Contains(text, "block") {
+			return models.RoleBlocker
+		}
+		if strings.Contains(text, "assist") {
+			return models.RoleAssister
+		}
+
+	case isTurnoverType(playType):
+		if index == 0 {
+			return models.RoleTurnover
+		}
+		if strings.Contains(text, "steal") || strings.Contains(text, "stolen") {
+			return models.RoleStealer
+		}
+
+	case strings.Contains(playType, "steal"):
+		if index == 0 {
+			return models.RoleStealer
+		}
+
+	case strings.Contains(playType, "block"):
+		if index == 0 {
+			return models.RoleBlocker
+		}
+
+	case strings.Contains(playType, "foul"):
+		if index == 0 {
+			return models.RoleFouler
+		}
+		return models.RoleFouled
+
+	case strings.Contains(playType, "rebound"):
+		if index == 0 {
+			return models.RoleRebounder
+		}
+
+	case strings.Contains(playType, "substitution"):
+		return models.RoleSubstitute
+	}
+
+	return models.RolePlayer
+}
+
+// isTurnoverType matches the turnover types; violations like traveling are
+// turnovers that do not say so.
+func isTurnoverType(playType string) bool {
+	return strings.Contains(playType, "turnover") || playType == "traveling"
+}
+
+// isShotType matches field goal and free throw types; "Block Shot" is the
+// defender's play, not a shot.
+func isShotType(playType string) bool {
+	if strings.Contains(playType, "block") {
+		return false
+	}
+	return strings.HasSuffix(playType, "shot") || strings.Contains(playType, "freethrow")
+}
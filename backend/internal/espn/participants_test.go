@@ -0,0 +1,129 @@
+package espn_test
+
+import (
+	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+func participants(ids ...string) []espn.Participant {
+	var out []espn.Participant
+	for _, id := range ids {
+		var p espn.Participant
+		p.Athlete.ID = id
+		out = append(out, p)
+	}
+	return out
+}
+
+func TestParticipantRoles(t *testing.T) {
+	tests := []struct {
+		name  string
+		play  espn.Play
+		roles []string
+	}{
+		{
+			name: "assisted jumper",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "JumpShot"}, ShootingPlay: true,
+				Text:         "Jaxon Okafor makes three point jumper. Assisted by Devin Marsh.",
+				Participants: participants("1", "2"),
+			},
+			roles: []string{models.RoleShooter, models.RoleAssister},
+		},
+		{
+			name: "blocked layup",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "LayUpShot"}, ShootingPlay: true,
+				Text:         "Jaxon Okafor misses layup. Blocked by Noah Pruitt.",
+				Participants: participants("1", "3"),
+			},
+			roles: []string{models.RoleShooter, models.RoleBlocker},
+		},
+		{
+			name: "free throw",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "MadeFreeThrow"}, ShootingPlay: true,
+				Text:         "Jaxon Okafor makes free throw 1 of 2.",
+				Participants: participants("1"),
+			},
+			roles: []string{models.RoleShooter},
+		},
+		{
+			name: "turnover with steal",
+			play: espn.Play{
+				Type:         espn.PlayType{Text: "Lost Ball Turnover"},
+				Text:         "Jaxon Okafor lost ball turnover. Stolen by Noah Pruitt.",
+				Participants: participants("1", "3"),
+			},
+			roles: []string{models.RoleTurnover, models.RoleStealer},
+		},
+		{
+			name: "traveling",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "Traveling"}, Text: "Jaxon Okafor traveling.",
+				Participants: participants("1"),
+			},
+			roles: []string{models.RoleTurnover},
+		},
+		{
+			name: "steal",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "Steal"}, Text: "Noah Pruitt steal.",
+				Participants: participants("3"),
+			},
+			roles: []string{models.RoleStealer},
+		},
+		{
+			name: "block",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "Block Shot"}, Text: "Noah Pruitt block.",
+				Participants: participants("3"),
+			},
+			roles: []string{models.RoleBlocker},
+		},
+		{
+			name: "foul",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "PersonalFoul"}, Text: "Foul on Noah Pruitt.",
+				Participants: participants("3", "1"),
+			},
+			roles: []string{models.RoleFouler, models.RoleFouled},
+		},
+		{
+			name: "rebound",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "Defensive Rebound"}, Text: "Noah Pruitt defensive rebound.",
+				Participants: participants("3"),
+			},
+			roles: []string{models.RoleRebounder},
+		},
+		{
+			name: "explicit participant type",
+			play: espn.Play{
+				Type: espn.PlayType{Text: "JumpShot"}, ShootingPlay: true,
+				Text: "Devin Marsh makes two point jumper.",
+				Participants: []espn.Participant{
+					{Type: "assister"},
+					{Type: "scorer"},
+				},
+			},
+			roles: []string{models.RoleAssister, models.RoleShooter},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.play.ParticipantRoles()
+			if len(got) != len(tt.roles) {
+				t.Fatalf("expected %d roles, got %+v", len(tt.roles), got)
+			}
+			for i, role := range tt.roles {
+				if got[i].Role != role {
+					t.Errorf("participant %d: expected %s, got %s", i, role, got[i].Role)
+				}
+			}
+		})
+	}
+}
@@ -1,31 +1,70 @@
-package espn
+package espn_test
 
 import (
-	"encoding/json"
-	"fmt"
-	"os"
+	"context"
 	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
 )
 
 func TestGetGameSummary(t *testing.T) {
-	client := NewClient("https://site.api.espn.com")
-
-	gameID := "401822893"
+	srv := espntest.NewServer()
+	defer srv.Close()
 
-	fmt.Printf("Fetching game summary for: %s\n", gameID)
+	client := espn.NewClient(srv.URL)
 
-	summary, err := client.GetGameSummary(gameID)
+	summary, err := client.GetGameSummary(context.Background(), espn.MensCollegeBasketball, espntest.FinalGameID)
 	if err != nil {
 		t.Fatalf("Error fetching game summary: %v", err)
 	}
 
-	fmt.Printf("\n✅ Game ID: %s\n", summary.Header.ID)
+	if summary.Header.ID != espntest.FinalGameID {
+		t.Errorf("expected header id %s, got %s", espntest.FinalGameID, summary.Header.ID)
+	}
+	if len(summary.Plays) == 0 {
+		t.Fatal("expected plays in a final game summary")
+	}
+	if len(summary.BoxScore.Players) != 2 {
+		t.Errorf("expected box score for 2 teams, got %d", len(summary.BoxScore.Players))
+	}
+
+	last := summary.Plays[len(summary.Plays)-1]
+	if last.Type.Text != "End Game" {
+		t.Errorf("expected final play to be End Game, got %q", last.Type.Text)
+	}
+	if last.HomeScore != 67 || last.AwayScore != 66 {
+		t.Errorf("expected final score 67-66, got %d-%d", last.HomeScore, last.AwayScore)
+	}
+
+	var shots, withCoords int
+	for _, play := range summary.Plays {
+		if play.ID[:9] != espntest.FinalGameID {
+			t.Fatalf("play %s does not carry the game id prefix", play.ID)
+		}
+		if play.ShootingPlay {
+			shots++
+			if play.Coordinate != nil {
+				withCoords++
+			}
+		}
+	}
+	if shots == 0 || withCoords == 0 {
+		t.Errorf("expected shooting plays with coordinates, got %d shots, %d with coordinates", shots, withCoords)
+	}
+}
 
-	data, _ := json.MarshalIndent(summary, "", "  ")
-	os.WriteFile("/tmp/game_summary.json", data, 0644)
-	fmt.Println("✅ Saved full response to /tmp/game_summary.json")
+func TestGetGameSummaryPreGame(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
 
-	fmt.Printf("\nChecking for plays...\n")
+	client := espn.NewClient(srv.URL)
 
-	t.Logf("Summary structure: %+v", summary)
+	summary, err := client.GetGameSummary(context.Background(), espn.MensCollegeBasketball, espntest.PreGameID)
+	if err != nil {
+		t.Fatalf("Error fetching game summary: %v", err)
+	}
+	if len(summary.Plays) != 0 {
+		t.Errorf("expected no plays before tip, got %d", len(summary.Plays))
+	}
 }
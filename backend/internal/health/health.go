@@ -0,0 +1,124 @@
+// Package health serves liveness and readiness endpoints for the API server
+// and the poller.
+//
+// /healthz answers 200 whenever the process can serve HTTP. /readyz runs the
+// registered dependency checks and answers 503 if a required one fails or
+// the process is draining for shutdown.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout bounds each dependency check during a readiness probe.
+const checkTimeout = 2 * time.Second
+
+type Check func(ctx context.Context) error
+
+type check struct {
+	name     string
+	fn       Check
+	required bool
+}
+
+type Checker struct {
+	checks   []check
+	draining atomic.Bool
+}
+
+func New() *Checker {
+	return &Checker{}
+}
+
+// Add registers a check that must pass for the process to be ready.
+func (c *Checker) Add(name string, fn Check) {
+	c.checks = append(c.checks, check{name: name, fn: fn, required: true})
+}
+
+// AddOptional registers a check that is reported but does not affect
+// readiness.
+func (c *Checker) AddOptional(name string, fn Check) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Drain marks the process as shutting down, failing readiness from now on.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+type report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func (c *Checker) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, report{Status: "ok"})
+}
+
+func (c *Checker) Readyz(w http.ResponseWriter, r *http.Request) {
+	rep := report{Status: "ready", Checks: make(map[string]string, len(c.checks))}
+
+	results := make([]error, len(c.checks))
+	var wg sync.WaitGroup
+	for i, chk := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+			defer cancel()
+			results[i] = chk.fn(ctx)
+		}()
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	for i, chk := range c.checks {
+		if results[i] == nil {
+			rep.Checks[chk.name] = "ok"
+			continue
+		}
+		rep.Checks[chk.name] = results[i].Error()
+		if chk.required {
+			rep.Status = "not ready"
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	if c.draining.Load() {
+		rep.Status = "draining"
+		status = http.StatusServiceUnavailable
+	}
+
+	writeReport(w, status, rep)
+}
+
+func writeReport(w http.ResponseWriter, status int, rep report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(rep)
+}
+
+// Cached wraps a check so it runs at most once per ttl, for dependencies
+// that should not be hit on every probe.
+func Cached(fn Check, ttl time.Duration) Check {
+	var (
+		mu      sync.Mutex
+		checked time.Time
+		last    error
+	)
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if !checked.IsZero() && time.Since(checked) < ttl {
+			return last
+		}
+		last = fn(ctx)
+		checked = time.Now()
+		return last
+	}
+}
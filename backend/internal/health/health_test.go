@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func probe(t *testing.T, h http.HandlerFunc) (int, report) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var rep report
+	if err := json.NewDecoder(rec.Body).Decode(&rep); err != nil {
+		t.Fatalf("decoding report: %v", err)
+	}
+	return rec.Code, rep
+}
+
+func TestReadyz(t *testing.T) {
+	var mongoErr error
+	c := New()
+	c.Add("mongo", func(context.Context) error { return mongoErr })
+	c.AddOptional("espn", func(context.Context) error { return errors.New("connection refused") })
+
+	code, rep := probe(t, c.Readyz)
+	if code != http.StatusOK || rep.Status != "ready" {
+		t.Errorf("expected ready with only an optional check failing, got %d %+v", code, rep)
+	}
+	if rep.Checks["mongo"] != "ok" || rep.Checks["espn"] != "connection refused" {
+		t.Errorf("unexpected check results %v", rep.Checks)
+	}
+
+	mongoErr = errors.New("server selection timeout")
+	code, rep = probe(t, c.Readyz)
+	if code != http.StatusServiceUnavailable || rep.Status != "not ready" {
+		t.Errorf("expected not ready when a required check fails, got %d %+v", code, rep)
+	}
+
+	mongoErr = nil
+	c.Drain()
+	code, rep = probe(t, c.Readyz)
+	if code != http.StatusServiceUnavailable || rep.Status != "draining" {
+		t.Errorf("expected draining after Drain, got %d %+v", code, rep)
+	}
+
+	if code, _ := probe(t, c.Healthz); code != http.StatusOK {
+		t.Errorf("expected healthz to stay up while draining, got %d", code)
+	}
+}
+
+func TestCached(t *testing.T) {
+	calls := 0
+	check := Cached(func(context.Context) error {
+		calls++
+		return nil
+	}, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		check(context.Background())
+	}
+	if calls != 1 {
+		t.Errorf("expected one underlying call within the ttl, got %d", calls)
+	}
+}
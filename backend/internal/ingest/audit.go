@@ -0,0 +1,76 @@
+package ingest
+
+import (
+	"slices"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// auditPlays compares the plays stored for a game with the ones in its
+// latest summary and returns an edit for every stored play ESPN has since
+// changed or dropped. Plays only in the summary are new, not edits.
+func auditPlays(stored, current []models.Play, now time.Time) []models.PlayEdit {
+	byID := make(map[string]models.Play, len(current))
+	for _, play := range current {
+		byID[play.ID] = play
+	}
+
+	var edits []models.PlayEdit
+	for _, old := range stored {
+		edit := models.PlayEdit{
+			GameID:         old.GameID,
+			PlayID:         old.ID,
+			SequenceNumber: old.SequenceNumber,
+			Before:         old,
+			DetectedAt:     now,
+		}
+
+		cur, ok := byID[old.ID]
+		if !ok {
+			edit.Kind = models.PlayDeleted
+			edits = append(edits, edit)
+			continue
+		}
+
+		if fields := changedFields(old, cur); len(fields) > 0 {
+			edit.Kind = models.PlayEdited
+			edit.Fields = fields
+			edit.After = &cur
+			edits = append(edits, edit)
+		}
+	}
+	return edits
+}
+
+// changedFields names, by their stored field names, what differs between
+// two versions of a play.
+func changedFields(a, b models.Play) []string {
+	var fields []string
+	check := func(name string, differs bool) {
+		if differs {
+			fields = append(fields, name)
+		}
+	}
+
+	check("sequence_number", a.SequenceNumber != b.SequenceNumber)
+	check("type", a.Type != b.Type || a.TypeID != b.TypeID)
+	check("text", a.Text != b.Text)
+	check("period", a.Period != b.Period)
+	check("clock", a.Clock != b.Clock)
+	check("score", a.AwayScore != b.AwayScore || a.HomeScore != b.HomeScore)
+	check("scoring_play", a.ScoringPlay != b.ScoringPlay || a.ScoreValue != b.ScoreValue)
+	check("shooting_play", a.ShootingPlay != b.ShootingPlay)
+	check("coordinate", !sameCoordinate(a.CoordinateX, b.CoordinateX) || !sameCoordinate(a.CoordinateY, b.CoordinateY))
+	check("team_id", a.TeamID != b.TeamID)
+	check("participants", !slices.Equal(a.PlayerIDs, b.PlayerIDs) || !slices.Equal(a.Participants, b.Participants))
+
+	return fields
+}
+
+func sameCoordinate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
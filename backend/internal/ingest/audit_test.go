@@ -0,0 +1,51 @@
+package ingest
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+func TestAuditPlays(t *testing.T) {
+	stored := loadPlays(t)
+
+	if edits := auditPlays(stored, stored, time.Now()); len(edits) != 0 {
+		t.Fatalf("expected no edits for an unchanged feed, got %d", len(edits))
+	}
+
+	// Reverse a made basket and drop a duplicate play.
+	var made int
+	for i, p := range stored {
+		if p.ScoringPlay && p.ScoreValue == 2 {
+			made = i
+			break
+		}
+	}
+	current := slices.Clone(stored)
+	current[made].ScoringPlay = false
+	current[made].ScoreValue = 0
+	current[made].Text = "Reversed"
+	dropped := current[made+1].ID
+	current = slices.Delete(current, made+1, made+2)
+
+	edits := auditPlays(stored, current, time.Now())
+	if len(edits) != 2 {
+		t.Fatalf("expected 2 edits, got %d: %+v", len(edits), edits)
+	}
+
+	edited, deleted := edits[0], edits[1]
+	if edited.Kind != models.PlayEdited || edited.PlayID != stored[made].ID {
+		t.Errorf("expected play %s edited, got %s %s", stored[made].ID, edited.Kind, edited.PlayID)
+	}
+	if !slices.Equal(edited.Fields, []string{"text", "scoring_play"}) {
+		t.Errorf("unexpected changed fields %v", edited.Fields)
+	}
+	if edited.After == nil || edited.Before.ScoreValue != 2 || edited.After.ScoreValue != 0 {
+		t.Errorf("expected before and after versions of the play, got %+v", edited)
+	}
+	if deleted.Kind != models.PlayDeleted || deleted.PlayID != dropped || deleted.After != nil {
+		t.Errorf("expected play %s deleted, got %+v", dropped, deleted)
+	}
+}
@@ -0,0 +1,116 @@
+package ingest
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/asallaram/cbb-analytics/internal/analyzer"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// gameState is what the pipeline remembers about a game between two
+// ingestions of its data: the highest sequence number ingested, a digest
+// of every ingested play to notice ESPN editing one, and the analyzer's
+// running totals.
+type gameState struct {
+	game    models.Game
+	lastSeq int
+	digests map[string]uint64
+
+	stats  *analyzer.StatsAccumulator
+	zones  *analyzer.ZoneAccumulator
+	recent []models.Play
+}
+
+func newGameState(game models.Game) *gameState {
+	return &gameState{
+		game:    game,
+		digests: make(map[string]uint64),
+		stats:   analyzer.NewStatsAccumulator(game),
+		zones:   analyzer.NewZoneAccumulator(),
+	}
+}
+
+// delta is the part of a game's play-by-play that needs work.
+type delta struct {
+	// write holds the plays to store: new ones, plus any earlier play ESPN
+	// has since changed.
+	write []models.Play
+	// fresh holds the plays to fold into the analyzer's running totals.
+	fresh []models.Play
+	// rebuild means an already-ingested play changed or went missing, so
+	// the totals have to be recomputed from every play.
+	rebuild bool
+}
+
+// diff compares a game's plays with what has already been ingested.
+// Plays past the last sequence number are new; a play at or before it that
+// is unknown, different or missing forces a rebuild.
+func (s *gameState) diff(plays []models.Play) delta {
+	var d delta
+	seen := 0
+	for _, play := range plays {
+		digest := playDigest(play)
+		old, known := s.digests[play.ID]
+		switch {
+		case known && old == digest:
+			seen++
+		case known:
+			seen++
+			d.write = append(d.write, play)
+			d.rebuild = true
+		case sequence(play) > s.lastSeq:
+			d.write = append(d.write, play)
+			d.fresh = append(d.fresh, play)
+		default:
+			d.write = append(d.write, play)
+			d.rebuild = true
+		}
+	}
+	if seen < len(s.digests) {
+		d.rebuild = true
+	}
+	if d.rebuild {
+		d.fresh = plays
+	}
+	return d
+}
+
+// apply folds a delta into the running totals and returns the stats and
+// zone lines that changed. After a rebuild every line counts as changed.
+func (s *gameState) apply(d delta) (map[string]*analyzer.PlayerStats, map[string]*analyzer.ZoneStats) {
+	if d.rebuild {
+		*s = *newGameState(s.game)
+	}
+
+	stats := s.stats.Add(d.fresh)
+	zones := s.zones.Add(d.fresh)
+
+	for _, play := range d.fresh {
+		s.digests[play.ID] = playDigest(play)
+		s.lastSeq = max(s.lastSeq, sequence(play))
+	}
+
+	s.recent = append(s.recent, d.fresh...)
+	if n := len(s.recent); n > analyzer.MomentumWindow {
+		s.recent = append([]models.Play(nil), s.recent[n-analyzer.MomentumWindow:]...)
+	}
+
+	if d.rebuild {
+		return s.stats.Stats(), s.zones.Stats()
+	}
+	return stats, zones
+}
+
+func sequence(play models.Play) int {
+	n, _ := strconv.Atoi(play.SequenceNumber)
+	return n
+}
+
+func playDigest(play models.Play) uint64 {
+	body, _ := json.Marshal(play)
+	h := fnv.New64a()
+	h.Write(body)
+	return h.Sum64()
+}
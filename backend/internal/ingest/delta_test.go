@@ -0,0 +1,99 @@
+package ingest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/analyzer"
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
+)
+
+// loadPlays returns the final game fixture's plays.
+func loadPlays(t *testing.T) []models.Play {
+	t.Helper()
+	return loadGame(t).Plays
+}
+
+// loadGame returns the final game fixture.
+func loadGame(t *testing.T) *source.GameData {
+	t.Helper()
+	summary, err := espntest.LoadSummary(espntest.League, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+	data, err := source.FromSummary(espn.MensCollegeBasketball, summary)
+	if err != nil {
+		t.Fatalf("converting fixture: %v", err)
+	}
+	return data
+}
+
+func TestGameStateIncremental(t *testing.T) {
+	data := loadGame(t)
+	plays := data.Plays
+
+	state := newGameState(data.Game)
+	written := 0
+	for end := 50; ; end += 37 {
+		end = min(end, len(plays))
+
+		d := state.diff(plays[:end])
+		if d.rebuild {
+			t.Fatalf("unexpected rebuild at %d plays", end)
+		}
+		if len(d.write) != end-written {
+			t.Fatalf("at %d plays: expected %d new plays, got %d", end, end-written, len(d.write))
+		}
+		state.apply(d)
+		written = end
+
+		if end == len(plays) {
+			break
+		}
+	}
+
+	if d := state.diff(plays); len(d.write) != 0 || len(d.fresh) != 0 {
+		t.Errorf("expected an unchanged summary to be a no-op, got %d writes", len(d.write))
+	}
+
+	want := analyzer.CalculatePlayerStats(data.Game, plays)
+	if !reflect.DeepEqual(state.stats.Stats(), want) {
+		t.Error("incremental stats differ from a full recompute")
+	}
+	if !reflect.DeepEqual(state.zones.Stats(), analyzer.CalculateZoneStats(plays)) {
+		t.Error("incremental zones differ from a full recompute")
+	}
+	if len(state.recent) != analyzer.MomentumWindow {
+		t.Errorf("expected the last %d plays to be kept, got %d", analyzer.MomentumWindow, len(state.recent))
+	}
+}
+
+func TestGameStateRebuild(t *testing.T) {
+	data := loadGame(t)
+	plays := data.Plays
+
+	state := newGameState(data.Game)
+	state.apply(state.diff(plays))
+
+	edited := append(plays[:0:0], plays...)
+	edited[10].Text += " (corrected)"
+	d := state.diff(edited)
+	if !d.rebuild || len(d.write) != 1 || len(d.fresh) != len(plays) {
+		t.Errorf("expected an edit to rewrite one play and rebuild, got rebuild=%v writes=%d fresh=%d",
+			d.rebuild, len(d.write), len(d.fresh))
+	}
+
+	removed := append(plays[:10:10], plays[11:]...)
+	d = state.diff(removed)
+	if !d.rebuild || len(d.write) != 0 {
+		t.Errorf("expected a removed play to rebuild without writes, got rebuild=%v writes=%d", d.rebuild, len(d.write))
+	}
+
+	changed, _ := state.apply(d)
+	if !reflect.DeepEqual(changed, analyzer.CalculatePlayerStats(data.Game, removed)) {
+		t.Error("expected a rebuild to return every recomputed stats line")
+	}
+}
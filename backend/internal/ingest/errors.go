@@ -0,0 +1,48 @@
+package ingest
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Pipeline stages, as recorded in the ingest error journal.
+const (
+	StagePlays         = "plays"
+	StagePlayEdits     = "play_edits"
+	StageBoxScore      = "box_score"
+	StagePlayers       = "players"
+	StageStats         = "stats"
+	StageDiscrepancies = "discrepancies"
+	StageZones         = "zones"
+	StageInsights      = "insights"
+	StageFinalize      = "finalize"
+)
+
+// StageError is a pipeline failure tagged with the stage it happened in.
+// Everything before that stage has been written.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+func failed(stage, doing string, err error) error {
+	return &StageError{Stage: stage, Err: fmt.Errorf("%s: %w", doing, err)}
+}
+
+// Stage returns the pipeline stage err came from, or "" if it did not come
+// from the pipeline.
+func Stage(err error) string {
+	var se *StageError
+	if errors.As(err, &se) {
+		return se.Stage
+	}
+	return ""
+}
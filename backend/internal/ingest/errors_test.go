@@ -0,0 +1,25 @@
+package ingest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStage(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := fmt.Errorf("ingesting 401822893: %w", failed(StageZones, "saving zones", cause))
+
+	if got := Stage(err); got != StageZones {
+		t.Errorf("expected stage %q, got %q", StageZones, got)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected the cause to be unwrappable")
+	}
+	if err.Error() != "ingesting 401822893: saving zones: connection reset" {
+		t.Errorf("unexpected message %q", err)
+	}
+	if got := Stage(cause); got != "" {
+		t.Errorf("expected no stage for a plain error, got %q", got)
+	}
+}
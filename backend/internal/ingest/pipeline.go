@@ -0,0 +1,265 @@
+// Package ingest runs game data from any source through storage and the
+// analyzer. The poller, the backfill, the reprocess command and replay all
+// share this path.
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/analyzer"
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
+	"github.com/asallaram/cbb-analytics/internal/storage"
+)
+
+type Pipeline struct {
+	DB *storage.MongoDB
+
+	// SkipBoxScore leaves the stored box score and reconciliation results
+	// alone. Replay sets it because a recorded box score describes the end
+	// of the capture, not the moment being replayed.
+	SkipBoxScore bool
+
+	// SkipAudit stores plays without auditing them against the stored
+	// ones. Reprocessing an older archived payload sets it, since plays
+	// missing from that payload were not deleted by ESPN. Plays already
+	// tombstoned are left out instead, so a snapshot taken before ESPN
+	// deleted them does not bring them back.
+	SkipAudit bool
+
+	// Thresholds tune insight generation; NewPipeline sets the defaults.
+	Thresholds analyzer.Thresholds
+
+	mu    sync.Mutex
+	games map[string]*gameState
+}
+
+func NewPipeline(db *storage.MongoDB) *Pipeline {
+	return &Pipeline{
+		DB:         db,
+		Thresholds: analyzer.DefaultThresholds,
+		games:      make(map[string]*gameState),
+	}
+}
+
+// state returns what the pipeline remembers about a game. Callers must not
+// ingest the same game from two goroutines at once.
+func (p *Pipeline) state(game models.Game) *gameState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s, ok := p.games[game.ID]
+	if !ok {
+		s = newGameState(game)
+		p.games[game.ID] = s
+	}
+	return s
+}
+
+// Reset forgets every game, for when another process may have ingested
+// them since they were last seen here.
+func (p *Pipeline) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.games = make(map[string]*gameState)
+}
+
+// Forget drops what the pipeline remembers about a game, so its next
+// ingestion starts over from the full play-by-play.
+func (p *Pipeline) Forget(gameID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.games, gameID)
+}
+
+type Result struct {
+	Plays         int
+	Players       int
+	Zones         int
+	Insights      int
+	Discrepancies int
+	Edits         int
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%d plays, %d players, %d zones, %d insights (%d stat discrepancies, %d play edits)",
+		r.Plays, r.Players, r.Zones, r.Insights, r.Discrepancies, r.Edits)
+}
+
+// Ingest writes a game's plays, box score and roster, then derives and
+// stores stats, zones, reconciliation results and insights. A game without
+// a box score skips the box score and reconciliation.
+//
+// The pipeline remembers each game between calls: only plays past the last
+// ingested sequence number are written and fed to the analyzer, and only
+// the stats and zone lines they touch are rewritten. The first call for a
+// game, or one where ESPN changed an earlier play, does the full game.
+//
+// On those full passes the stored plays are also audited against the feed:
+// plays the source dropped are tombstoned, every correction is recorded in
+// play_edits, and stats, zones and insights are recomputed from scratch.
+// Edits and tombstones are written before the plays themselves, since once
+// the corrected plays are stored the audit has nothing left to find.
+func (p *Pipeline) Ingest(ctx context.Context, data *source.GameData) (Result, error) {
+	var res Result
+	gameID := data.Game.ID
+
+	if p.SkipAudit {
+		deleted, err := p.DB.GetTombstonedPlayIDs(ctx, gameID)
+		if err != nil {
+			return res, failed(StagePlays, "loading tombstoned plays", err)
+		}
+		data = withoutPlays(data, deleted)
+	}
+
+	state := p.state(data.Game)
+	first := len(state.digests) == 0
+	d := state.diff(data.Plays)
+
+	if (first || d.rebuild) && !p.SkipAudit {
+		stored, err := p.DB.GetPlaysByGame(ctx, gameID)
+		if err != nil {
+			return res, failed(StagePlays, "loading stored plays", err)
+		}
+		edits := auditPlays(stored, data.Plays, time.Now())
+		if len(edits) > 0 {
+			d.rebuild = true
+			d.fresh = data.Plays
+		}
+
+		if err := p.DB.SavePlayEdits(ctx, edits); err != nil {
+			return res, failed(StagePlayEdits, "saving play edits", err)
+		}
+		var deleted []string
+		for _, e := range edits {
+			if e.Kind == models.PlayDeleted {
+				deleted = append(deleted, e.PlayID)
+			}
+		}
+		if err := p.DB.TombstonePlays(ctx, gameID, deleted, time.Now()); err != nil {
+			return res, failed(StagePlays, "tombstoning plays", err)
+		}
+		res.Edits = len(edits)
+	}
+
+	if err := p.DB.UpsertPlays(ctx, d.write); err != nil {
+		return res, failed(StagePlays, "saving plays", err)
+	}
+	res.Plays = len(d.write)
+
+	// A full pass rewrites every derived line, so whatever it does not
+	// rewrite is stale. If clearing that fails, the game is forgotten so
+	// the next call makes another full pass.
+	full := first || d.rebuild
+
+	box := data.BoxScore
+	if p.SkipBoxScore {
+		box = nil
+	}
+	if box != nil {
+		if err := p.DB.UpsertBoxScore(ctx, box); err != nil {
+			return res, failed(StageBoxScore, "saving box score", err)
+		}
+	}
+
+	roster := data.Roster
+	if err := p.DB.UpsertPlayers(ctx, roster); err != nil {
+		return res, failed(StagePlayers, "saving players", err)
+	}
+
+	// From here on the totals are ahead of what is stored: if a write fails
+	// they are dropped, so the next call redoes the full game rather than
+	// skipping the lines this call never wrote.
+	changedStats, changedZones := state.apply(d)
+	if err := p.DB.UpsertPlayerStats(ctx, changedStats); err != nil {
+		p.Forget(gameID)
+		return res, failed(StageStats, "saving stats", err)
+	}
+	res.Players = len(changedStats)
+
+	if box != nil {
+		discrepancies := analyzer.Reconcile(gameID, state.stats.Stats(), box)
+		if err := p.DB.ReplaceDiscrepancies(ctx, gameID, discrepancies); err != nil {
+			p.Forget(gameID)
+			return res, failed(StageDiscrepancies, "saving stat discrepancies", err)
+		}
+		res.Discrepancies = len(discrepancies)
+	}
+
+	if err := p.DB.UpsertZoneStats(ctx, changedZones); err != nil {
+		p.Forget(gameID)
+		return res, failed(StageZones, "saving zones", err)
+	}
+	res.Zones = len(changedZones)
+
+	if full {
+		statPlayers := slices.AppendSeq([]string{}, maps.Keys(state.stats.Stats()))
+		zonePlayers := slices.AppendSeq([]string{}, maps.Keys(state.zones.Stats()))
+		if err := p.DB.DeleteStaleStats(ctx, gameID, statPlayers, zonePlayers); err != nil {
+			p.Forget(gameID)
+			return res, failed(StageStats, "removing stale stats", err)
+		}
+	}
+
+	playerNames := make(map[string]string, len(roster))
+	for _, player := range roster {
+		playerNames[player.ID] = player.DisplayName
+	}
+
+	generator := analyzer.NewInsightGeneratorFromTotals(state.recent, state.stats.Stats(), state.zones.Stats(), playerNames)
+	generator.Thresholds = p.Thresholds
+	insights := generator.GenerateInsights(gameID)
+	if full {
+		if err := p.DB.DeleteInsights(ctx, gameID); err != nil {
+			p.Forget(gameID)
+			return res, failed(StageInsights, "clearing insights", err)
+		}
+	}
+	if err := p.DB.SaveInsights(ctx, insights); err != nil {
+		if full {
+			p.Forget(gameID)
+		}
+		return res, failed(StageInsights, "saving insights", err)
+	}
+	res.Insights = len(insights)
+
+	return res, nil
+}
+
+// withoutPlays returns data minus the plays whose IDs are in ids.
+func withoutPlays(data *source.GameData, ids map[string]bool) *source.GameData {
+	if len(ids) == 0 {
+		return data
+	}
+	kept := *data
+	kept.Plays = make([]models.Play, 0, len(data.Plays))
+	for _, play := range data.Plays {
+		if !ids[play.ID] {
+			kept.Plays = append(kept.Plays, play)
+		}
+	}
+	return &kept
+}
+
+// Finalize runs one last full ingestion of a finished game and marks the
+// game finalized, after which the poller leaves it alone.
+func (p *Pipeline) Finalize(ctx context.Context, data *source.GameData) (Result, error) {
+	gameID := data.Game.ID
+	p.Forget(gameID)
+	defer p.Forget(gameID)
+
+	res, err := p.Ingest(ctx, data)
+	if err != nil {
+		return res, err
+	}
+
+	if err := p.DB.MarkGameFinalized(ctx, gameID, time.Now()); err != nil {
+		return res, failed(StageFinalize, "marking game finalized", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,24 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"github.com/asallaram/cbb-analytics/internal/source"
+)
+
+func TestWithoutPlays(t *testing.T) {
+	data := &source.GameData{Plays: []models.Play{{ID: "1"}, {ID: "2"}, {ID: "3"}}}
+
+	if got := withoutPlays(data, nil); got != data {
+		t.Error("expected no tombstones to leave the data as is")
+	}
+
+	got := withoutPlays(data, map[string]bool{"2": true})
+	if len(got.Plays) != 2 || got.Plays[0].ID != "1" || got.Plays[1].ID != "3" {
+		t.Errorf("expected plays 1 and 3, got %+v", got.Plays)
+	}
+	if len(data.Plays) != 3 {
+		t.Error("original plays were modified")
+	}
+}
@@ -5,18 +5,51 @@ import (
 )
 
 type Game struct {
-	ID            string    `bson:"id" json:"id"`
-	Date          string    `bson:"date" json:"date"`
+	ID     string `bson:"id" json:"id"`
+	League string `bson:"league" json:"league"`
+	Date   string `bson:"date" json:"date"`
+	// GameDay is the Eastern calendar day of the tip, stored as midnight UTC.
+	GameDay       time.Time `bson:"game_day,omitempty" json:"game_day"`
 	HomeTeamID    string    `bson:"home_team_id" json:"home_team_id"`
 	HomeTeamName  string    `bson:"home_team_name" json:"home_team_name"`
 	AwayTeamID    string    `bson:"away_team_id" json:"away_team_id"`
 	AwayTeamName  string    `bson:"away_team_name" json:"away_team_name"`
 	Status        string    `bson:"status" json:"status"`
+	StatusName    string    `bson:"status_name,omitempty" json:"status_name,omitempty"`
 	CurrentPeriod int       `bson:"current_period" json:"current_period"`
 	CurrentClock  string    `bson:"current_clock" json:"current_clock"`
 	HomeScore     int       `bson:"home_score" json:"home_score"`
 	AwayScore     int       `bson:"away_score" json:"away_score"`
 	LastUpdated   time.Time `bson:"last_updated" json:"last_updated"`
+
+	HomeRank       int       `bson:"home_rank,omitempty" json:"home_rank,omitempty"`
+	AwayRank       int       `bson:"away_rank,omitempty" json:"away_rank,omitempty"`
+	HomeRecord     string    `bson:"home_record,omitempty" json:"home_record,omitempty"`
+	AwayRecord     string    `bson:"away_record,omitempty" json:"away_record,omitempty"`
+	Conference     string    `bson:"conference,omitempty" json:"conference,omitempty"`
+	ConferenceSlug string    `bson:"conference_slug,omitempty" json:"conference_slug,omitempty"`
+	NeutralSite    bool      `bson:"neutral_site" json:"neutral_site"`
+	VenueName      string    `bson:"venue_name,omitempty" json:"venue_name,omitempty"`
+	VenueCity      string    `bson:"venue_city,omitempty" json:"venue_city,omitempty"`
+	VenueState     string    `bson:"venue_state,omitempty" json:"venue_state,omitempty"`
+	Attendance     int       `bson:"attendance,omitempty" json:"attendance,omitempty"`
+	Broadcast      string    `bson:"broadcast,omitempty" json:"broadcast,omitempty"`
+	TipTime        time.Time `bson:"tip_time" json:"tip_time"`
+
+	// Finalized is set once the poller has ingested the game's final
+	// summary. Both fields are omitempty so that routine upserts from the
+	// scoreboard never clear them.
+	Finalized   bool      `bson:"finalized,omitempty" json:"finalized"`
+	FinalizedAt time.Time `bson:"finalized_at,omitempty" json:"finalized_at,omitempty"`
+
+	// Replay marks a copy of a recorded game fed through the poller's
+	// replay mode. Game listings leave replays out unless asked for them.
+	Replay bool `bson:"replay,omitempty" json:"replay,omitempty"`
+}
+
+// Matchup names the game the way ESPN titles events, "Away at Home".
+func (g Game) Matchup() string {
+	return g.AwayTeamName + " at " + g.HomeTeamName
 }
 
 type Score struct {
@@ -32,6 +65,16 @@ type Team struct {
 	Logo         string `bson:"logo" json:"logo"`
 }
 
+type Player struct {
+	ID          string    `bson:"_id" json:"id"`
+	DisplayName string    `bson:"display_name" json:"display_name"`
+	ShortName   string    `bson:"short_name" json:"short_name"`
+	Jersey      string    `bson:"jersey" json:"jersey"`
+	Position    string    `bson:"position" json:"position"`
+	TeamID      string    `bson:"team_id" json:"team_id"`
+	LastUpdated time.Time `bson:"last_updated" json:"last_updated"`
+}
+
 type Insight struct {
 	GameID    string    `bson:"game_id" json:"game_id"`
 	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
@@ -45,28 +88,179 @@ type Insight struct {
 }
 
 type Context struct {
-	TeamID   string                 `bson:"team_id,omitempty" json:"team_id,omitempty"`
-	PlayerID string                 `bson:"player_id,omitempty" json:"player_id,omitempty"`
-	Zone     string                 `bson:"zone,omitempty" json:"zone,omitempty"`
-	Stats    map[string]interface{} `bson:"stats,omitempty" json:"stats,omitempty"`
+	TeamID     string                 `bson:"team_id,omitempty" json:"team_id,omitempty"`
+	PlayerID   string                 `bson:"player_id,omitempty" json:"player_id,omitempty"`
+	PlayerName string                 `bson:"player_name,omitempty" json:"player_name,omitempty"`
+	Zone       string                 `bson:"zone,omitempty" json:"zone,omitempty"`
+	Stats      map[string]interface{} `bson:"stats,omitempty" json:"stats,omitempty"`
 }
 type Play struct {
-	ID             string   `bson:"id" json:"id"`
-	GameID         string   `bson:"game_id" json:"game_id"`
-	SequenceNumber string   `bson:"sequence_number" json:"sequence_number"`
-	Type           string   `bson:"type" json:"type"`
-	TypeID         string   `bson:"type_id" json:"type_id"`
-	Text           string   `bson:"text" json:"text"`
-	Period         int      `bson:"period" json:"period"`
-	Clock          string   `bson:"clock" json:"clock"`
-	AwayScore      int      `bson:"away_score" json:"away_score"`
-	HomeScore      int      `bson:"home_score" json:"home_score"`
-	ScoringPlay    bool     `bson:"scoring_play" json:"scoring_play"`
-	ScoreValue     int      `bson:"score_value" json:"score_value"`
-	ShootingPlay   bool     `bson:"shooting_play" json:"shooting_play"`
-	CoordinateX    *float64 `bson:"coordinate_x,omitempty" json:"coordinate_x,omitempty"`
-	CoordinateY    *float64 `bson:"coordinate_y,omitempty" json:"coordinate_y,omitempty"`
-	TeamID         string   `bson:"team_id,omitempty" json:"team_id,omitempty"`
-	PlayerIDs      []string `bson:"player_ids,omitempty" json:"player_ids,omitempty"`
-	Timestamp      string   `bson:"timestamp" json:"timestamp"`
+	ID             string            `bson:"id" json:"id"`
+	GameID         string            `bson:"game_id" json:"game_id"`
+	SequenceNumber string            `bson:"sequence_number" json:"sequence_number"`
+	Type           string            `bson:"type" json:"type"`
+	TypeID         string            `bson:"type_id" json:"type_id"`
+	Text           string            `bson:"text" json:"text"`
+	Period         int               `bson:"period" json:"period"`
+	Clock          string            `bson:"clock" json:"clock"`
+	AwayScore      int               `bson:"away_score" json:"away_score"`
+	HomeScore      int               `bson:"home_score" json:"home_score"`
+	ScoringPlay    bool              `bson:"scoring_play" json:"scoring_play"`
+	ScoreValue     int               `bson:"score_value" json:"score_value"`
+	ShootingPlay   bool              `bson:"shooting_play" json:"shooting_play"`
+	CoordinateX    *float64          `bson:"coordinate_x,omitempty" json:"coordinate_x,omitempty"`
+	CoordinateY    *float64          `bson:"coordinate_y,omitempty" json:"coordinate_y,omitempty"`
+	TeamID         string            `bson:"team_id,omitempty" json:"team_id,omitempty"`
+	PlayerIDs      []string          `bson:"player_ids,omitempty" json:"player_ids,omitempty"`
+	PlayerNames    []string          `bson:"-" json:"player_names,omitempty"`
+	Participants   []PlayParticipant `bson:"participants,omitempty" json:"participants,omitempty"`
+	Timestamp      string            `bson:"timestamp" json:"timestamp"`
+
+	// Deleted tombstones a play ESPN has since removed from the feed.
+	Deleted   bool      `bson:"deleted,omitempty" json:"-"`
+	DeletedAt time.Time `bson:"deleted_at,omitempty" json:"-"`
+}
+
+// Play edit kinds.
+const (
+	PlayEdited  = "edited"
+	PlayDeleted = "deleted"
+)
+
+// PlayEdit records a correction ESPN made to a play after it was stored.
+type PlayEdit struct {
+	GameID         string    `bson:"game_id" json:"game_id"`
+	PlayID         string    `bson:"play_id" json:"play_id"`
+	SequenceNumber string    `bson:"sequence_number" json:"sequence_number"`
+	Kind           string    `bson:"kind" json:"kind"`
+	Fields         []string  `bson:"fields,omitempty" json:"fields,omitempty"`
+	Before         Play      `bson:"before" json:"before"`
+	After          *Play     `bson:"after,omitempty" json:"after,omitempty"`
+	DetectedAt     time.Time `bson:"detected_at" json:"detected_at"`
+}
+
+// Participant roles. Sources tag each athlete on a play with one of these.
+const (
+	RoleShooter    = "shooter"
+	RoleAssister   = "assister"
+	RoleBlocker    = "blocker"
+	RoleStealer    = "stealer"
+	RoleFouler     = "fouler"
+	RoleFouled     = "fouled"
+	RoleRebounder  = "rebounder"
+	RoleTurnover   = "turnover"
+	RoleSubstitute = "substitute"
+	RolePlayer     = "player"
+)
+
+type PlayParticipant struct {
+	PlayerID   string `bson:"player_id" json:"player_id"`
+	PlayerName string `bson:"-" json:"player_name,omitempty"`
+	Role       string `bson:"role" json:"role"`
+}
+
+// Athlete returns the first participant with the given role, or "".
+func (p Play) Athlete(role string) string {
+	for _, participant := range p.Participants {
+		if participant.Role == role {
+			return participant.PlayerID
+		}
+	}
+	return ""
+}
+
+type BoxScore struct {
+	GameID      string           `bson:"game_id" json:"game_id"`
+	Teams       []TeamBoxScore   `bson:"teams" json:"teams"`
+	Players     []PlayerBoxScore `bson:"players" json:"players"`
+	LastUpdated time.Time        `bson:"last_updated" json:"last_updated"`
+}
+
+type TeamBoxScore struct {
+	TeamID       string `bson:"team_id" json:"team_id"`
+	TeamName     string `bson:"team_name" json:"team_name"`
+	HomeAway     string `bson:"home_away" json:"home_away"`
+	BoxScoreLine `bson:",inline"`
+}
+
+type PlayerBoxScore struct {
+	PlayerID     string `bson:"player_id" json:"player_id"`
+	PlayerName   string `bson:"player_name" json:"player_name"`
+	Jersey       string `bson:"jersey" json:"jersey"`
+	Position     string `bson:"position" json:"position"`
+	TeamID       string `bson:"team_id" json:"team_id"`
+	Starter      bool   `bson:"starter" json:"starter"`
+	DidNotPlay   bool   `bson:"did_not_play" json:"did_not_play"`
+	BoxScoreLine `bson:",inline"`
+}
+
+type BoxScoreLine struct {
+	Minutes     int `bson:"minutes" json:"minutes"`
+	FGM         int `bson:"fgm" json:"fgm"`
+	FGA         int `bson:"fga" json:"fga"`
+	ThreePM     int `bson:"three_pm" json:"three_pm"`
+	ThreePA     int `bson:"three_pa" json:"three_pa"`
+	FTM         int `bson:"ftm" json:"ftm"`
+	FTA         int `bson:"fta" json:"fta"`
+	OffRebounds int `bson:"off_rebounds" json:"off_rebounds"`
+	DefRebounds int `bson:"def_rebounds" json:"def_rebounds"`
+	Rebounds    int `bson:"rebounds" json:"rebounds"`
+	Assists     int `bson:"assists" json:"assists"`
+	Steals      int `bson:"steals" json:"steals"`
+	Blocks      int `bson:"blocks" json:"blocks"`
+	Turnovers   int `bson:"turnovers" json:"turnovers"`
+	Fouls       int `bson:"fouls" json:"fouls"`
+	Points      int `bson:"points" json:"points"`
+}
+
+// Checkpoint marks a scoreboard day whose final games have all been
+// backfilled, so an interrupted backfill can resume where it stopped.
+type Checkpoint struct {
+	ID          string    `bson:"_id" json:"id"`
+	League      string    `bson:"league" json:"league"`
+	Date        string    `bson:"date" json:"date"`
+	Games       int       `bson:"games" json:"games"`
+	Ingested    int       `bson:"ingested" json:"ingested"`
+	CompletedAt time.Time `bson:"completed_at" json:"completed_at"`
+}
+
+// IngestError journals a game whose ingestion failed part way through. It
+// stays open, and is retried with backoff, until an ingestion of the game
+// succeeds.
+type IngestError struct {
+	GameID        string    `bson:"game_id" json:"game_id"`
+	League        string    `bson:"league" json:"league"`
+	Stage         string    `bson:"stage" json:"stage"`
+	Error         string    `bson:"error" json:"error"`
+	Attempts      int       `bson:"attempts" json:"attempts"`
+	FirstFailedAt time.Time `bson:"first_failed_at" json:"first_failed_at"`
+	LastFailedAt  time.Time `bson:"last_failed_at" json:"last_failed_at"`
+	// NextAttemptAt is unset once automatic retries are exhausted.
+	NextAttemptAt time.Time `bson:"next_attempt_at,omitempty" json:"next_attempt_at,omitempty"`
+	Resolved      bool      `bson:"resolved" json:"resolved"`
+	ResolvedAt    time.Time `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+// Schema drift kinds.
+const (
+	DriftUnknownField = "unknown_field"
+	DriftMissingField = "missing_field"
+	DriftPlayType     = "play_type"
+)
+
+// SchemaDrift tallies one way ESPN payloads have differed from what
+// decoding expects, across every fetch it turned up in.
+type SchemaDrift struct {
+	League  string `bson:"league" json:"league"`
+	Payload string `bson:"payload" json:"payload"`
+	Kind    string `bson:"kind" json:"kind"`
+	// Name is the field path, or the play type text.
+	Name    string `bson:"name" json:"name"`
+	Fetches int    `bson:"fetches" json:"fetches"`
+	// Objects counts the plays, events or competitors missing a field.
+	Objects   int       `bson:"objects,omitempty" json:"objects,omitempty"`
+	FirstSeen time.Time `bson:"first_seen" json:"first_seen"`
+	LastSeen  time.Time `bson:"last_seen" json:"last_seen"`
+	// LastKey is the scoreboard date or game ID it was last seen in.
+	LastKey string `bson:"last_key" json:"last_key"`
 }
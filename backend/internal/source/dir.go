@@ -0,0 +1,119 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// Dir reads games from JSON files on local disk, one GameData document per
+// game, at <root>/<league>/<YYYYMMDD>/<game-id>.json. Files can be written
+// by hand or with Put; a game's plays are re-read on every fetch, so editing
+// a file while the poller runs feeds the change through like a live update.
+type Dir struct {
+	Root string
+}
+
+func (d Dir) Games(ctx context.Context, league, day string) ([]models.Game, error) {
+	files, err := os.ReadDir(filepath.Join(d.Root, league, day))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var games []models.Game
+	for _, f := range files {
+		gameID, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok || f.IsDir() {
+			continue
+		}
+		data, err := d.read(league, filepath.Join(d.Root, league, day, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("game %s: %w", gameID, err)
+		}
+		games = append(games, data.Game)
+	}
+
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].TipTime.Before(games[j].TipTime)
+	})
+	return games, nil
+}
+
+func (d Dir) Game(ctx context.Context, league, gameID string) (*GameData, error) {
+	paths, err := filepath.Glob(filepath.Join(d.Root, league, "*", gameID+".json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%s %s: %w", league, gameID, ErrNotFound)
+	}
+	return d.read(league, paths[0])
+}
+
+// Put writes a game under its game day, replacing any earlier file for it.
+func (d Dir) Put(data *GameData) error {
+	if data.Game.ID == "" || data.Game.GameDay.IsZero() {
+		return fmt.Errorf("game needs an ID and a game day to be stored")
+	}
+
+	body, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(d.Root, data.Game.League, data.Game.GameDay.Format("20060102"), data.Game.ID+".json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write then rename so a reader never sees a half-written file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// read decodes a game file and fills in what hand-written files usually
+// leave out: the league, the game day, and the game ID on every play and the
+// box score.
+func (d Dir) read(league, path string) (*GameData, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var data GameData
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+
+	if data.Game.ID == "" {
+		data.Game.ID = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	if data.Game.League == "" {
+		data.Game.League = league
+	}
+	if data.Game.GameDay.IsZero() {
+		data.Game.GameDay, _ = time.Parse("20060102", filepath.Base(filepath.Dir(path)))
+	}
+	for i := range data.Plays {
+		if data.Plays[i].GameID == "" {
+			data.Plays[i].GameID = data.Game.ID
+		}
+	}
+	if data.BoxScore != nil && data.BoxScore.GameID == "" {
+		data.BoxScore.GameID = data.Game.ID
+	}
+	return &data, nil
+}
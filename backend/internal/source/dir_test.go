@@ -0,0 +1,87 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+func TestDirRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := Dir{Root: t.TempDir()}
+
+	summary, err := espntest.LoadSummary(espntest.League, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+	data, err := FromSummary(espn.MensCollegeBasketball, summary)
+	if err != nil {
+		t.Fatalf("FromSummary: %v", err)
+	}
+	if err := dir.Put(data); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	games, err := dir.Games(ctx, espntest.League, espntest.GameDate)
+	if err != nil {
+		t.Fatalf("Games: %v", err)
+	}
+	if len(games) != 1 || games[0].ID != espntest.FinalGameID {
+		t.Fatalf("expected the stored game, got %+v", games)
+	}
+
+	got, err := dir.Game(ctx, espntest.League, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("Game: %v", err)
+	}
+	if len(got.Plays) != len(data.Plays) || got.BoxScore == nil || len(got.Roster) != len(data.Roster) {
+		t.Errorf("game did not round-trip: %d plays, %d players", len(got.Plays), len(got.Roster))
+	}
+
+	if _, err := dir.Game(ctx, espntest.League, "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing game, got %v", err)
+	}
+	if games, err := dir.Games(ctx, espntest.League, "20260704"); err != nil || len(games) != 0 {
+		t.Errorf("expected no games on an empty day, got %d (%v)", len(games), err)
+	}
+}
+
+func TestDirHandWritten(t *testing.T) {
+	dir := Dir{Root: t.TempDir()}
+
+	path := filepath.Join(dir.Root, "mens-college-basketball", "20260315", "9001.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	body := `{
+		"game": {"status": "in", "home_team_name": "Home", "away_team_name": "Away"},
+		"plays": [{"id": "1", "sequence_number": "1", "type": "JumpShot", "participants": [{"player_id": "7", "role": "shooter"}]}]
+	}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := dir.Game(context.Background(), "mens-college-basketball", "9001")
+	if err != nil {
+		t.Fatalf("Game: %v", err)
+	}
+	if data.Game.ID != "9001" || data.Game.League != "mens-college-basketball" {
+		t.Errorf("expected ID and league from the path, got %q and %q", data.Game.ID, data.Game.League)
+	}
+	if !data.Game.GameDay.Equal(time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the game day from the path, got %v", data.Game.GameDay)
+	}
+	if data.Plays[0].GameID != "9001" || data.Plays[0].Athlete(models.RoleShooter) != "7" {
+		t.Errorf("unexpected play %+v", data.Plays[0])
+	}
+	if data.BoxScore != nil {
+		t.Error("expected no box score")
+	}
+}
@@ -0,0 +1,58 @@
+package source
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// ESPN reads games from the ESPN site API.
+type ESPN struct {
+	Client *espn.Client
+}
+
+func (s ESPN) Games(ctx context.Context, league, day string) ([]models.Game, error) {
+	scoreboard, err := s.Client.GetScoreboard(ctx, espn.League(league), day)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]models.Game, 0, len(scoreboard.Events))
+	for _, event := range scoreboard.Events {
+		if len(event.Competitions) == 0 {
+			continue
+		}
+		games = append(games, GameFromEvent(espn.League(league), event, event.Competitions[0]))
+	}
+	return games, nil
+}
+
+func (s ESPN) Game(ctx context.Context, league, gameID string) (*GameData, error) {
+	summary, err := s.Client.GetGameSummary(ctx, espn.League(league), gameID)
+	if err != nil {
+		return nil, err
+	}
+	return FromSummary(espn.League(league), summary)
+}
+
+// FromSummary converts a decoded ESPN game summary, live or archived.
+func FromSummary(league espn.League, summary *espn.GameSummary) (*GameData, error) {
+	game, ok := GameFromSummary(league, summary)
+	if !ok {
+		return nil, fmt.Errorf("game %s summary has no header competition", summary.Header.ID)
+	}
+
+	box, err := espn.ParseBoxScore(game.ID, summary.BoxScore)
+	if err != nil {
+		return nil, fmt.Errorf("parsing box score: %w", err)
+	}
+
+	return &GameData{
+		Game:     game,
+		Plays:    playModels(game.ID, summary.Plays),
+		BoxScore: box,
+		Roster:   espn.ParseRoster(summary.BoxScore),
+	}, nil
+}
@@ -0,0 +1,41 @@
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+)
+
+func TestESPNSource(t *testing.T) {
+	srv := espntest.NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	src := ESPN{Client: espn.NewClient(srv.URL)}
+
+	games, err := src.Games(ctx, espntest.League, espntest.GameDate)
+	if err != nil {
+		t.Fatalf("Games: %v", err)
+	}
+	if len(games) != 3 {
+		t.Fatalf("expected 3 games, got %d", len(games))
+	}
+
+	data, err := src.Game(ctx, espntest.League, espntest.FinalGameID)
+	if err != nil {
+		t.Fatalf("Game: %v", err)
+	}
+	if data.Game.ID != espntest.FinalGameID || data.Game.Status != "post" {
+		t.Errorf("unexpected game %s (%s)", data.Game.ID, data.Game.Status)
+	}
+	if len(data.Plays) == 0 || data.BoxScore == nil || len(data.Roster) == 0 {
+		t.Fatalf("expected plays, a box score and a roster, got %d plays, %d players", len(data.Plays), len(data.Roster))
+	}
+	for _, play := range data.Plays {
+		if play.GameID != espntest.FinalGameID {
+			t.Fatalf("play %s has game ID %q", play.ID, play.GameID)
+		}
+	}
+}
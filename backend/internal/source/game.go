@@ -0,0 +1,136 @@
+package source
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// GameFromEvent converts a scoreboard event into the stored game document.
+func GameFromEvent(league espn.League, event espn.Event, comp espn.Competition) models.Game {
+	game := models.Game{
+		ID:            event.ID,
+		League:        string(league),
+		Date:          event.Date,
+		Status:        event.Status.Type.State,
+		StatusName:    event.Status.Type.Name,
+		CurrentPeriod: event.Status.Period,
+		CurrentClock:  event.Status.DisplayClock,
+		LastUpdated:   time.Now(),
+		NeutralSite:   comp.NeutralSite,
+		VenueName:     comp.Venue.FullName,
+		VenueCity:     comp.Venue.Address.City,
+		VenueState:    comp.Venue.Address.State,
+		Attendance:    comp.Attendance,
+		Broadcast:     broadcastName(comp.Broadcasts),
+		TipTime:       parseTipTime(event.Date),
+	}
+
+	if comp.Groups != nil && comp.Groups.IsConference {
+		game.Conference = comp.Groups.ShortName
+		game.ConferenceSlug = slugify(comp.Groups.ShortName)
+	}
+
+	for _, competitor := range comp.Competitors {
+		if competitor.HomeAway == "home" {
+			game.HomeTeamID = competitor.Team.ID
+			game.HomeTeamName = competitor.Team.DisplayName
+			game.HomeScore = parseInt(competitor.Score)
+			game.HomeRank = apRank(competitor.CuratedRank)
+			game.HomeRecord = overallRecord(competitor.Records)
+		} else {
+			game.AwayTeamID = competitor.Team.ID
+			game.AwayTeamName = competitor.Team.DisplayName
+			game.AwayScore = parseInt(competitor.Score)
+			game.AwayRank = apRank(competitor.CuratedRank)
+			game.AwayRecord = overallRecord(competitor.Records)
+		}
+	}
+
+	if !game.TipTime.IsZero() {
+		game.GameDay = espn.GameDay(game.TipTime)
+	}
+
+	return game
+}
+
+// apRank returns the poll position, or 0 for unranked teams.
+func apRank(rank *espn.Rank) int {
+	if rank == nil || rank.Current < 1 || rank.Current > 25 {
+		return 0
+	}
+	return rank.Current
+}
+
+func overallRecord(records []espn.Record) string {
+	for _, r := range records {
+		if r.Type == "total" {
+			return r.Summary
+		}
+	}
+	return ""
+}
+
+// broadcastName prefers the national broadcast over regional ones.
+func broadcastName(broadcasts []espn.Broadcast) string {
+	var fallback string
+	for _, b := range broadcasts {
+		if len(b.Names) == 0 {
+			continue
+		}
+		if b.Market == "national" {
+			return strings.Join(b.Names, "/")
+		}
+		if fallback == "" {
+			fallback = strings.Join(b.Names, "/")
+		}
+	}
+	return fallback
+}
+
+// parseTipTime handles ESPN's minute-precision "2026-03-15T16:00Z" as well
+// as full RFC 3339 timestamps.
+func parseTipTime(s string) time.Time {
+	for _, layout := range []string{"2006-01-02T15:04Z07:00", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func slugify(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), "-")
+}
+
+func parseInt(s string) int {
+	var val int
+	fmt.Sscanf(s, "%d", &val)
+	return val
+}
+
+// GameFromSummary builds the game document from a summary's header, for
+// callers that have a summary but no scoreboard event.
+func GameFromSummary(league espn.League, summary *espn.GameSummary) (models.Game, bool) {
+	if len(summary.Header.Competitions) == 0 {
+		return models.Game{}, false
+	}
+	hc := summary.Header.Competitions[0]
+
+	event := espn.Event{
+		ID:     summary.Header.ID,
+		Date:   hc.Date,
+		Status: hc.Status,
+	}
+	comp := espn.Competition{
+		ID:                    hc.ID,
+		Date:                  hc.Date,
+		NeutralSite:           hc.NeutralSite,
+		ConferenceCompetition: hc.ConferenceCompetition,
+		Competitors:           hc.Competitors,
+	}
+	return GameFromEvent(league, event, comp), true
+}
@@ -0,0 +1,61 @@
+package source
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/espn/espntest"
+)
+
+func TestGameFromEvent(t *testing.T) {
+	scoreboard, err := espntest.LoadScoreboard(espntest.League, espntest.GameDate)
+	if err != nil {
+		t.Fatalf("loading fixture: %v", err)
+	}
+
+	games := make(map[string]espn.Event)
+	for _, event := range scoreboard.Events {
+		games[event.ID] = event
+	}
+
+	event := games[espntest.FinalGameID]
+	game := GameFromEvent(espn.MensCollegeBasketball, event, event.Competitions[0])
+
+	if game.HomeTeamName != "Michigan State Spartans" || game.HomeScore != 67 || game.AwayScore != 66 {
+		t.Errorf("unexpected teams/score: %+v", game)
+	}
+	if game.HomeRank != 8 || game.AwayRank != 11 {
+		t.Errorf("expected ranks 8 and 11, got %d and %d", game.HomeRank, game.AwayRank)
+	}
+	if game.HomeRecord != "24-7" || game.AwayRecord != "22-9" {
+		t.Errorf("unexpected records %q and %q", game.HomeRecord, game.AwayRecord)
+	}
+	if game.Conference != "Big Ten" || game.ConferenceSlug != "big-ten" {
+		t.Errorf("unexpected conference %q (%q)", game.Conference, game.ConferenceSlug)
+	}
+	if game.VenueName != "Breslin Center" || game.VenueCity != "East Lansing" || game.Attendance != 14797 || game.NeutralSite {
+		t.Errorf("unexpected venue info: %+v", game)
+	}
+	if game.Broadcast != "CBS" {
+		t.Errorf("expected CBS, got %q", game.Broadcast)
+	}
+	if !game.TipTime.Equal(time.Date(2026, 3, 15, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected tip time %v", game.TipTime)
+	}
+	if !game.GameDay.Equal(time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected game day %v", game.GameDay)
+	}
+
+	event = games[espntest.LiveGameID]
+	game = GameFromEvent(espn.MensCollegeBasketball, event, event.Competitions[0])
+	if game.HomeRank != 0 || game.AwayRank != 3 {
+		t.Errorf("expected unranked home team and #3 away team, got %d and %d", game.HomeRank, game.AwayRank)
+	}
+
+	event = games[espntest.PreGameID]
+	game = GameFromEvent(espn.MensCollegeBasketball, event, event.Competitions[0])
+	if !game.NeutralSite || game.ConferenceSlug != "big-12" || game.Broadcast != "ESPN2" {
+		t.Errorf("unexpected pre-game metadata: %+v", game)
+	}
+}
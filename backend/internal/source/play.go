@@ -0,0 +1,57 @@
+package source
+
+import (
+	"github.com/asallaram/cbb-analytics/internal/espn"
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// playModel converts an ESPN play into the stored play document.
+func playModel(gameID string, p espn.Play) models.Play {
+	play := models.Play{
+		ID:             p.ID,
+		GameID:         gameID,
+		SequenceNumber: p.SequenceNumber,
+		Type:           p.Type.Text,
+		TypeID:         p.Type.ID,
+		Text:           p.Text,
+		Period:         p.Period.Number,
+		Clock:          p.Clock.DisplayValue,
+		AwayScore:      p.AwayScore,
+		HomeScore:      p.HomeScore,
+		ScoringPlay:    p.ScoringPlay,
+		ScoreValue:     p.ScoreValue,
+		ShootingPlay:   p.ShootingPlay,
+		Timestamp:      p.Wallclock,
+	}
+
+	if p.Coordinate != nil {
+		play.CoordinateX = &p.Coordinate.X
+		play.CoordinateY = &p.Coordinate.Y
+	}
+
+	if p.Team != nil {
+		play.TeamID = p.Team.ID
+	}
+
+	if len(p.Participants) > 0 {
+		for _, participant := range p.Participants {
+			play.PlayerIDs = append(play.PlayerIDs, participant.Athlete.ID)
+		}
+		for _, r := range p.ParticipantRoles() {
+			play.Participants = append(play.Participants, models.PlayParticipant{
+				PlayerID: r.AthleteID,
+				Role:     r.Role,
+			})
+		}
+	}
+
+	return play
+}
+
+func playModels(gameID string, plays []espn.Play) []models.Play {
+	out := make([]models.Play, 0, len(plays))
+	for _, p := range plays {
+		out = append(out, playModel(gameID, p))
+	}
+	return out
+}
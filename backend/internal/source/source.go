@@ -0,0 +1,34 @@
+// Package source abstracts where game data comes from. The poller and the
+// backfill list a day's games and fetch each game's plays and box score
+// through a DataSource, so ESPN is one feed among others: a directory of
+// hand-curated JSON files works the same way.
+package source
+
+import (
+	"context"
+	"errors"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+)
+
+// ErrNotFound is returned when a source has no data for a game.
+var ErrNotFound = errors.New("game not found")
+
+// GameData is everything the ingest pipeline needs about one game, in the
+// stored, provider-neutral models.
+type GameData struct {
+	Game  models.Game   `json:"game"`
+	Plays []models.Play `json:"plays"`
+
+	// BoxScore is nil when the source has none; the game's stored box
+	// score and reconciliation are then left alone.
+	BoxScore *models.BoxScore `json:"box_score,omitempty"`
+	Roster   []models.Player  `json:"roster,omitempty"`
+}
+
+// DataSource lists games and fetches their play-by-play. day is a
+// YYYYMMDD date in US Eastern time, the same day games are stored under.
+type DataSource interface {
+	Games(ctx context.Context, league, day string) ([]models.Game, error)
+	Game(ctx context.Context, league, gameID string) (*GameData, error)
+}
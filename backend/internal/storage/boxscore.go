@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (m *MongoDB) UpsertBoxScore(ctx context.Context, box *models.BoxScore) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"game_id": box.GameID}
+	update := bson.M{"$set": box}
+	opts := options.Update().SetUpsert(true)
+
+	_, err := m.DB.Collection("box_scores").UpdateOne(ctx, filter, update, opts)
+	return err
+}
+
+func (m *MongoDB) GetBoxScore(ctx context.Context, gameID string) (*models.BoxScore, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	var box models.BoxScore
+	err := m.DB.Collection("box_scores").FindOne(ctx, bson.M{"game_id": gameID}).Decode(&box)
+	if err != nil {
+		return nil, err
+	}
+
+	return &box, nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func checkpointID(league, date string) string {
+	return league + "/" + date
+}
+
+func (m *MongoDB) SaveCheckpoint(ctx context.Context, cp *models.Checkpoint) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	cp.ID = checkpointID(cp.League, cp.Date)
+	filter := bson.M{"_id": cp.ID}
+	opts := options.Replace().SetUpsert(true)
+
+	_, err := m.DB.Collection("backfill_checkpoints").ReplaceOne(ctx, filter, cp, opts)
+	return err
+}
+
+// GetCheckpoints returns a league's checkpoints for days from through to,
+// both YYYYMMDD and inclusive.
+func (m *MongoDB) GetCheckpoints(ctx context.Context, league, from, to string) ([]models.Checkpoint, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"league": league, "date": bson.M{"$gte": from, "$lte": to}}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+
+	cursor, err := m.DB.Collection("backfill_checkpoints").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var checkpoints []models.Checkpoint
+	if err := cursor.All(ctx, &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
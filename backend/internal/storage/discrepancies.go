@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/asallaram/cbb-analytics/internal/analyzer"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReplaceDiscrepancies swaps a game's reconciliation results for the latest run.
+func (m *MongoDB) ReplaceDiscrepancies(ctx context.Context, gameID string, discrepancies []analyzer.Discrepancy) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	coll := m.DB.Collection("stat_discrepancies")
+	if _, err := coll.DeleteMany(ctx, bson.M{"game_id": gameID}); err != nil {
+		return err
+	}
+
+	if len(discrepancies) == 0 {
+		return nil
+	}
+
+	var docs []interface{}
+	for _, d := range discrepancies {
+		docs = append(docs, d)
+	}
+
+	_, err := coll.InsertMany(ctx, docs)
+	return err
+}
+
+func (m *MongoDB) GetDiscrepancies(ctx context.Context, gameID string) ([]analyzer.Discrepancy, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"game_id": gameID}
+	opts := options.Find().SetSort(bson.D{{Key: "player_id", Value: 1}, {Key: "stat", Value: 1}})
+
+	cursor, err := m.DB.Collection("stat_discrepancies").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var discrepancies []analyzer.Discrepancy
+	if err := cursor.All(ctx, &discrepancies); err != nil {
+		return nil, err
+	}
+
+	return discrepancies, nil
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// gameCollections hold documents derived from a game's play-by-play.
+var gameCollections = []string{"plays", "play_edits", "live_stats", "zone_stats", "insights", "stat_discrepancies"}
+
+// DeleteGameData removes everything derived from a game's play-by-play,
+// leaving the game document, box score and players registry in place.
+func (m *MongoDB) DeleteGameData(ctx context.Context, gameID string) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	for _, name := range gameCollections {
+		if _, err := m.DB.Collection(name).DeleteMany(ctx, bson.M{"game_id": gameID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkGameFinalized records that a game's final summary has been ingested.
+func (m *MongoDB) MarkGameFinalized(ctx context.Context, gameID string, at time.Time) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"finalized": true, "finalized_at": at}}
+	_, err := m.DB.Collection("games").UpdateOne(ctx, bson.M{"_id": gameID}, update)
+	return err
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RecordIngestError adds a failed attempt to a game's open journal entry,
+// opening one if the game has none, and returns the updated entry.
+func (m *MongoDB) RecordIngestError(ctx context.Context, gameID, league, stage, msg string, now time.Time) (*models.IngestError, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"game_id": gameID, "resolved": false}
+	update := bson.M{
+		"$set": bson.M{
+			"league":         league,
+			"stage":          stage,
+			"error":          msg,
+			"last_failed_at": now,
+		},
+		"$inc":         bson.M{"attempts": 1},
+		"$setOnInsert": bson.M{"first_failed_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var entry models.IngestError
+	if err := m.DB.Collection("ingest_errors").FindOneAndUpdate(ctx, filter, update, opts).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ScheduleIngestRetry sets when a game's open entry is next retried. A zero
+// time stops automatic retries.
+func (m *MongoDB) ScheduleIngestRetry(ctx context.Context, gameID string, at time.Time) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"next_attempt_at": at}}
+	if at.IsZero() {
+		update = bson.M{"$unset": bson.M{"next_attempt_at": ""}}
+	}
+
+	_, err := m.DB.Collection("ingest_errors").UpdateOne(ctx, bson.M{"game_id": gameID, "resolved": false}, update)
+	return err
+}
+
+// ResolveIngestError closes a game's open entry after a successful ingestion.
+func (m *MongoDB) ResolveIngestError(ctx context.Context, gameID string, now time.Time) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"game_id": gameID, "resolved": false}
+	update := bson.M{
+		"$set":   bson.M{"resolved": true, "resolved_at": now},
+		"$unset": bson.M{"next_attempt_at": ""},
+	}
+
+	_, err := m.DB.Collection("ingest_errors").UpdateMany(ctx, filter, update)
+	return err
+}
+
+// GetDueIngestRetries returns the open entries whose next retry is due.
+func (m *MongoDB) GetDueIngestRetries(ctx context.Context, now time.Time) ([]models.IngestError, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"resolved": false, "next_attempt_at": bson.M{"$lte": now}}
+	opts := options.Find().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}})
+
+	cursor, err := m.DB.Collection("ingest_errors").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.IngestError
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetIngestErrors returns journal entries, most recent failure first. Only
+// open entries are returned unless includeResolved is set.
+func (m *MongoDB) GetIngestErrors(ctx context.Context, includeResolved bool, limit int) ([]models.IngestError, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"resolved": false}
+	if includeResolved {
+		filter = bson.M{}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "last_failed_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := m.DB.Collection("ingest_errors").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.IngestError
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
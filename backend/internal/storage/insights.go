@@ -8,8 +8,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (m *MongoDB) SaveInsights(insights []models.Insight) error {
-	ctx := context.Background()
+func (m *MongoDB) SaveInsights(ctx context.Context, insights []models.Insight) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
 
 	if len(insights) == 0 {
 		return nil
@@ -24,8 +25,18 @@ func (m *MongoDB) SaveInsights(insights []models.Insight) error {
 	return err
 }
 
-func (m *MongoDB) GetInsights(gameID string, limit int) ([]models.Insight, error) {
-	ctx := context.Background()
+// DeleteInsights clears a game's insights so they can be regenerated.
+func (m *MongoDB) DeleteInsights(ctx context.Context, gameID string) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	_, err := m.DB.Collection("insights").DeleteMany(ctx, bson.M{"game_id": gameID})
+	return err
+}
+
+func (m *MongoDB) GetInsights(ctx context.Context, gameID string, limit int) ([]models.Insight, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
 
 	filter := bson.M{"game_id": gameID}
 	opts := options.Find().
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AcquireLease takes the named lease for holder until now+ttl, or extends
+// it if holder already has it. It reports false without error while another
+// holder's lease is unexpired. Expiry uses the caller's clock, so ttl should
+// comfortably exceed any clock skew between holders.
+func (m *MongoDB) AcquireLease(ctx context.Context, name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"holder": holder},
+			bson.M{"expires_at": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"holder": holder, "expires_at": now.Add(ttl), "renewed_at": now}}
+	opts := options.Update().SetUpsert(true)
+
+	_, err := m.DB.Collection("leases").UpdateOne(ctx, filter, update, opts)
+	if mongo.IsDuplicateKeyError(err) {
+		// The lease exists and is held by someone else, so the filter
+		// missed and the upsert collided with it.
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReleaseLease gives up the named lease if holder has it, so another
+// instance can take over without waiting for it to expire.
+func (m *MongoDB) ReleaseLease(ctx context.Context, name, holder string) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	_, err := m.DB.Collection("leases").DeleteOne(ctx, bson.M{"_id": name, "holder": holder})
+	return err
+}
@@ -14,6 +14,9 @@ import (
 type MongoDB struct {
 	client *mongo.Client
 	DB     *mongo.Database
+
+	// OpTimeout bounds every storage call on top of the caller's context.
+	OpTimeout time.Duration
 }
 
 func NewMongoDB(uri, dbName string) (*MongoDB, error) {
@@ -31,22 +34,33 @@ func NewMongoDB(uri, dbName string) (*MongoDB, error) {
 
 	db := client.Database(dbName)
 
-	if err := createIndexes(db); err != nil {
+	if err := createIndexes(ctx, db); err != nil {
 		return nil, fmt.Errorf("failed to create indexes: %w", err)
 	}
 
 	return &MongoDB{
-		client: client,
-		DB:     db,
+		client:    client,
+		DB:        db,
+		OpTimeout: 10 * time.Second,
 	}, nil
 }
 
-func createIndexes(db *mongo.Database) error {
-	ctx := context.Background()
+// opContext derives the per-call deadline for a storage operation.
+func (m *MongoDB) opContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if m.OpTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, m.OpTimeout)
+}
+
+func createIndexes(ctx context.Context, db *mongo.Database) error {
 
 	_, err := db.Collection("games").Indexes().CreateMany(ctx, []mongo.IndexModel{
 		{Keys: bson.D{{Key: "date", Value: 1}, {Key: "status", Value: 1}}},
 		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "league", Value: 1}, {Key: "game_day", Value: 1}}},
+		{Keys: bson.D{{Key: "game_day", Value: 1}, {Key: "tip_time", Value: 1}}},
+		{Keys: bson.D{{Key: "conference_slug", Value: 1}}},
 	})
 	if err != nil {
 		return err
@@ -69,18 +83,86 @@ func createIndexes(db *mongo.Database) error {
 	_, err = db.Collection("live_stats").Indexes().CreateMany(ctx, []mongo.IndexModel{
 		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "player_id", Value: 1}}},
 	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("box_scores").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "game_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("stat_discrepancies").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "player_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("play_edits").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "detected_at", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The archive's GridFS bucket, listed by payload key and pruned by age.
+	_, err = db.Collection("raw_payloads.files").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{
+			{Key: "metadata.league", Value: 1}, {Key: "metadata.kind", Value: 1},
+			{Key: "metadata.key", Value: 1}, {Key: "metadata.fetched_at", Value: 1},
+		}},
+		{Keys: bson.D{{Key: "metadata.fetched_at", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("backfill_checkpoints").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "league", Value: 1}, {Key: "date", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("ingest_errors").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "game_id", Value: 1}, {Key: "resolved", Value: 1}}},
+		{Keys: bson.D{{Key: "resolved", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "last_failed_at", Value: -1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("schema_drift").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "league", Value: 1}, {Key: "payload", Value: 1}, {Key: "kind", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "last_seen", Value: -1}}},
+	})
 
 	return err
 }
 
+// Ping checks that the primary is reachable.
+func (m *MongoDB) Ping(ctx context.Context) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+	return m.client.Ping(ctx, nil)
+}
+
 func (m *MongoDB) Close() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	return m.client.Disconnect(ctx)
 }
 
-func (m *MongoDB) UpsertGame(game *models.Game) error {
-	ctx := context.Background()
+func (m *MongoDB) UpsertGame(ctx context.Context, game *models.Game) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
 
 	filter := bson.M{"_id": game.ID}
 	update := bson.M{"$set": game}
@@ -90,8 +172,9 @@ func (m *MongoDB) UpsertGame(game *models.Game) error {
 	return err
 }
 
-func (m *MongoDB) GetGame(gameID string) (*models.Game, error) {
-	ctx := context.Background()
+func (m *MongoDB) GetGame(ctx context.Context, gameID string) (*models.Game, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
 
 	var game models.Game
 	err := m.DB.Collection("games").FindOne(ctx, bson.M{"_id": gameID}).Decode(&game)
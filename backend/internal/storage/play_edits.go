@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (m *MongoDB) SavePlayEdits(ctx context.Context, edits []models.PlayEdit) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	if len(edits) == 0 {
+		return nil
+	}
+
+	var docs []interface{}
+	for _, e := range edits {
+		docs = append(docs, e)
+	}
+
+	_, err := m.DB.Collection("play_edits").InsertMany(ctx, docs)
+	return err
+}
+
+// GetPlayEdits returns a game's play corrections, oldest first.
+func (m *MongoDB) GetPlayEdits(ctx context.Context, gameID string) ([]models.PlayEdit, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"game_id": gameID}
+	opts := options.Find().SetSort(bson.D{{Key: "detected_at", Value: 1}, {Key: "sequence_number", Value: 1}})
+
+	cursor, err := m.DB.Collection("play_edits").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var edits []models.PlayEdit
+	if err := cursor.All(ctx, &edits); err != nil {
+		return nil, err
+	}
+
+	return edits, nil
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (m *MongoDB) UpsertPlayers(ctx context.Context, players []models.Player) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	if len(players) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(players))
+	for _, player := range players {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": player.ID}).
+			SetUpdate(bson.M{"$set": player}).
+			SetUpsert(true))
+	}
+
+	_, err := m.DB.Collection("players").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (m *MongoDB) GetPlayers(ctx context.Context, playerIDs []string) ([]models.Player, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	if len(playerIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := m.DB.Collection("players").Find(ctx, bson.M{"_id": bson.M{"$in": playerIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var players []models.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, err
+	}
+
+	return players, nil
+}
+
+// GetPlayerNames resolves player IDs to display names. IDs missing from the
+// registry are left out of the map.
+func (m *MongoDB) GetPlayerNames(ctx context.Context, playerIDs []string) (map[string]string, error) {
+	players, err := m.GetPlayers(ctx, playerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(players))
+	for _, p := range players {
+		names[p.ID] = p.DisplayName
+	}
+	return names, nil
+}
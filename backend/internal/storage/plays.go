@@ -2,75 +2,90 @@ package storage
 
 import (
 	"context"
+	"time"
 
-	"github.com/asallaram/cbb-analytics/internal/espn"
 	"github.com/asallaram/cbb-analytics/internal/models"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (m *MongoDB) UpsertPlays(gameID string, plays []espn.Play) error {
-	ctx := context.Background()
+// UpsertPlays writes plays in a single bulk write, keyed by play ID. A play
+// that was tombstoned and has reappeared in the feed is brought back.
+func (m *MongoDB) UpsertPlays(ctx context.Context, plays []models.Play) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
 
 	if len(plays) == 0 {
 		return nil
 	}
 
-	var modelPlays []interface{}
-	for _, p := range plays {
-		play := models.Play{
-			ID:             p.ID,
-			GameID:         gameID,
-			SequenceNumber: p.SequenceNumber,
-			Type:           p.Type.Text,
-			TypeID:         p.Type.ID,
-			Text:           p.Text,
-			Period:         p.Period.Number,
-			Clock:          p.Clock.DisplayValue,
-			AwayScore:      p.AwayScore,
-			HomeScore:      p.HomeScore,
-			ScoringPlay:    p.ScoringPlay,
-			ScoreValue:     p.ScoreValue,
-			ShootingPlay:   p.ShootingPlay,
-			Timestamp:      p.Wallclock,
-		}
-
-		if p.Coordinate != nil {
-			play.CoordinateX = &p.Coordinate.X
-			play.CoordinateY = &p.Coordinate.Y
+	writes := make([]mongo.WriteModel, 0, len(plays))
+	for _, play := range plays {
+		update := bson.M{
+			"$set":   play,
+			"$unset": bson.M{"deleted": "", "deleted_at": ""},
 		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"id": play.ID}).
+			SetUpdate(update).
+			SetUpsert(true))
+	}
 
-		if p.Team != nil {
-			play.TeamID = p.Team.ID
-		}
+	_, err := m.DB.Collection("plays").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
 
-		if len(p.Participants) > 0 {
-			for _, participant := range p.Participants {
-				play.PlayerIDs = append(play.PlayerIDs, participant.Athlete.ID)
-			}
-		}
+// TombstonePlays marks plays ESPN has dropped from the feed as deleted.
+// They stay in the collection for the audit trail but are no longer served.
+func (m *MongoDB) TombstonePlays(ctx context.Context, gameID string, playIDs []string, at time.Time) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
 
-		modelPlays = append(modelPlays, play)
+	if len(playIDs) == 0 {
+		return nil
 	}
 
-	for _, play := range modelPlays {
-		filter := bson.M{"id": play.(models.Play).ID}
-		update := bson.M{"$set": play}
-		opts := options.Update().SetUpsert(true)
+	filter := bson.M{"game_id": gameID, "id": bson.M{"$in": playIDs}}
+	update := bson.M{"$set": bson.M{"deleted": true, "deleted_at": at}}
 
-		_, err := m.DB.Collection("plays").UpdateOne(ctx, filter, update, opts)
-		if err != nil {
-			return err
-		}
+	_, err := m.DB.Collection("plays").UpdateMany(ctx, filter, update)
+	return err
+}
+
+// GetTombstonedPlayIDs returns the IDs of a game's tombstoned plays.
+func (m *MongoDB) GetTombstonedPlayIDs(ctx context.Context, gameID string) (map[string]bool, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{"game_id": gameID, "deleted": true}
+	opts := options.Find().SetProjection(bson.M{"id": 1})
+
+	cursor, err := m.DB.Collection("plays").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID string `bson:"id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
 	}
 
-	return nil
+	ids := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		ids[d.ID] = true
+	}
+	return ids, nil
 }
 
-func (m *MongoDB) GetPlaysByGame(gameID string) ([]models.Play, error) {
-	ctx := context.Background()
+func (m *MongoDB) GetPlaysByGame(ctx context.Context, gameID string) ([]models.Play, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
 
-	filter := bson.M{"game_id": gameID}
+	filter := bson.M{"game_id": gameID, "deleted": bson.M{"$ne": true}}
 	opts := options.Find().SetSort(bson.M{"sequence_number": 1})
 
 	cursor, err := m.DB.Collection("plays").Find(ctx, filter, opts)
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/asallaram/cbb-analytics/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RecordSchemaDrift adds one fetch's findings to their running tallies.
+func (m *MongoDB) RecordSchemaDrift(ctx context.Context, findings []models.SchemaDrift) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	if len(findings) == 0 {
+		return nil
+	}
+
+	var writes []mongo.WriteModel
+	for _, f := range findings {
+		filter := bson.M{"league": f.League, "payload": f.Payload, "kind": f.Kind, "name": f.Name}
+		update := bson.M{
+			"$inc": bson.M{"fetches": f.Fetches, "objects": f.Objects},
+			"$min": bson.M{"first_seen": f.FirstSeen},
+			"$max": bson.M{"last_seen": f.LastSeen},
+			"$set": bson.M{"last_key": f.LastKey},
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	_, err := m.DB.Collection("schema_drift").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// GetSchemaDrift returns the drift tallies, most recently seen first,
+// optionally narrowed to a league and a kind.
+func (m *MongoDB) GetSchemaDrift(ctx context.Context, league, kind string) ([]models.SchemaDrift, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	filter := bson.M{}
+	if league != "" {
+		filter["league"] = league
+	}
+	if kind != "" {
+		filter["kind"] = kind
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_seen", Value: -1}})
+
+	cursor, err := m.DB.Collection("schema_drift").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var drift []models.SchemaDrift
+	if err := cursor.All(ctx, &drift); err != nil {
+		return nil, err
+	}
+	return drift, nil
+}
@@ -5,44 +5,107 @@ import (
 
 	"github.com/asallaram/cbb-analytics/internal/analyzer"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (m *MongoDB) UpsertPlayerStats(stats map[string]*analyzer.PlayerStats) error {
-	ctx := context.Background()
+func (m *MongoDB) UpsertPlayerStats(ctx context.Context, stats map[string]*analyzer.PlayerStats) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
 
+	if len(stats) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(stats))
 	for _, stat := range stats {
 		filter := bson.M{
 			"game_id":   stat.GameID,
 			"player_id": stat.PlayerID,
 		}
-		update := bson.M{"$set": stat}
-		opts := options.Update().SetUpsert(true)
-
-		_, err := m.DB.Collection("live_stats").UpdateOne(ctx, filter, update, opts)
-		if err != nil {
-			return err
-		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(bson.M{"$set": stat}).
+			SetUpsert(true))
 	}
 
-	return nil
+	_, err := m.DB.Collection("live_stats").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
 }
-func (m *MongoDB) UpsertZoneStats(stats map[string]*analyzer.ZoneStats) error {
-	ctx := context.Background()
 
+func (m *MongoDB) UpsertZoneStats(ctx context.Context, stats map[string]*analyzer.ZoneStats) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	if len(stats) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(stats))
 	for _, stat := range stats {
 		filter := bson.M{
 			"game_id":   stat.GameID,
 			"player_id": stat.PlayerID,
 		}
-		update := bson.M{"$set": stat}
-		opts := options.Update().SetUpsert(true)
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(bson.M{"$set": stat}).
+			SetUpsert(true))
+	}
 
-		_, err := m.DB.Collection("zone_stats").UpdateOne(ctx, filter, update, opts)
-		if err != nil {
+	_, err := m.DB.Collection("zone_stats").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// DeleteStaleStats removes a game's stats lines for players not in
+// statPlayers and zone lines for players not in zonePlayers, after a
+// correction has taken those credits away.
+func (m *MongoDB) DeleteStaleStats(ctx context.Context, gameID string, statPlayers, zonePlayers []string) error {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	keep := map[string][]string{"live_stats": statPlayers, "zone_stats": zonePlayers}
+	for name, players := range keep {
+		filter := bson.M{"game_id": gameID, "player_id": bson.M{"$nin": players}}
+		if _, err := m.DB.Collection(name).DeleteMany(ctx, filter); err != nil {
 			return err
 		}
 	}
-
 	return nil
 }
+
+func (m *MongoDB) GetPlayerStats(ctx context.Context, gameID string) ([]analyzer.PlayerStats, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	cursor, err := m.DB.Collection("live_stats").Find(ctx, bson.M{"game_id": gameID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stats []analyzer.PlayerStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (m *MongoDB) GetZoneStats(ctx context.Context, gameID string) ([]analyzer.ZoneStats, error) {
+	ctx, cancel := m.opContext(ctx)
+	defer cancel()
+
+	cursor, err := m.DB.Collection("zone_stats").Find(ctx, bson.M{"game_id": gameID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stats []analyzer.ZoneStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}